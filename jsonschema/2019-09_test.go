@@ -0,0 +1,70 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+const path201909 = "../../../json-schema-org/JSON-Schema-Test-Suite/tests/draft2019-09/"
+
+var supported201909 = &Supported{
+	passingFiles: map[string]bool{
+		"anchor.json":                true,
+		"boolean_schema.json":        true,
+		"contains.json":              true,
+		"default.json":               true,
+		"exclusiveMaximum.json":      true,
+		"exclusiveMinimum.json":      true,
+		"maxContains.json":           true,
+		"maximum.json":               true,
+		"minContains.json":           true,
+		"minimum.json":               true,
+		"multipleOf.json":            true,
+		"pattern.json":               true,
+		"propertyNames.json":         true,
+		"recursiveRef.json":          true,
+		"ref.json":                   true,
+		"refRemote.json":             true,
+		"required.json":              true,
+		"unevaluatedItems.json":      true,
+		"unevaluatedProperties.json": true,
+		"vocabulary.json":            true,
+
+		// optional
+		"optional/anchor.json": true,
+		"optional/id.json":     true,
+	},
+	skippingFiles: map[string]bool{
+		"uniqueItems.json": true, // only supported with WithUniqueItems, see TestSuiteDraft201909UniqueItems
+	},
+	passingTests:  map[string]bool{},
+	skippingTests: map[string]bool{},
+}
+
+func TestSuiteDraft201909(t *testing.T) {
+	runTests(t, path201909, supported201909, WithDefaultVersion(schema.VersionDraft2019))
+}
+
+var supportedUniqueItems201909 = &Supported{
+	onlyFiles:    map[string]bool{"uniqueItems.json": true},
+	passingFiles: map[string]bool{"uniqueItems.json": true},
+}
+
+func TestSuiteDraft201909UniqueItems(t *testing.T) {
+	runTests(t, path201909, supportedUniqueItems201909, WithDefaultVersion(schema.VersionDraft2019), WithUniqueItems())
+}
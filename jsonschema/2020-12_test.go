@@ -26,31 +26,31 @@ var supported202012 = &Supported{
 	passingFiles: map[string]bool{
 		// "additionalProperties.json":    true,
 		// "allOf.json": true,
-		// "anchor.json":                  true,
+		"anchor.json": true,
 		// "anyOf.json":                   true,
-		// "boolean_schema.json":          true,
+		"boolean_schema.json": true,
 		// "const.json":                   true,
-		// "contains.json":                true,
-		// "content.json":                 true,
-		"default.json": true,
+		"contains.json": true,
+		"content.json":  true,
+		"default.json":  true,
 		// "defs.json":                    true,
 		// "dependentRequired.json":       true,
 		// "dependentSchemas.json":        true,
-		// "dynamicRef.json":              true,
+		"dynamicRef.json": true,
 		// "enum.json":                    true,
 		"exclusiveMaximum.json": true,
 		"exclusiveMinimum.json": true,
 		"format.json":           true,
 		// "if-then-else.json":            true,
 		// "infinite-loop-detection.json": true,
-		// "items.json":                   true,
-		// "maxContains.json":             true,
-		"maximum.json": true,
+		"items.json":       true,
+		"maxContains.json": true,
+		"maximum.json":     true,
 		// "maxItems.json":                true,
 		// "maxLength.json":               true,
 		// "maxProperties.json":           true,
-		// "minContains.json":             true,
-		"minimum.json": true,
+		"minContains.json": true,
+		"minimum.json":     true,
 		// "minItems.json":                true,
 		// "minLength.json":               true,
 		// "minProperties.json":           true,
@@ -59,25 +59,25 @@ var supported202012 = &Supported{
 		// "oneOf.json":                   true,
 		"pattern.json": true,
 		// "patternProperties.json":       true,
-		// "prefixItems.json":             true,
+		"prefixItems.json": true,
 		// "properties.json": true,
-		// "propertyNames.json": true,
-		// "ref.json":                     true,
-		// "refRemote.json":               true,
-		"required.json": true,
+		"propertyNames.json": true,
+		"ref.json":           true,
+		"refRemote.json":     true,
+		"required.json":      true,
 		// "type.json":                    true,
-		// "unevaluatedItems.json":        true,
-		// "unevaluatedProperties.json":   true,
-		// "vocabulary.json":              true,
+		"unevaluatedItems.json":      true,
+		"unevaluatedProperties.json": true,
+		"vocabulary.json":            true,
 
 		// optional
-		// "optional/anchor.json":                     true,
-		// "optional/cross-draft.json":                true,
+		"optional/anchor.json":      true,
+		"optional/cross-draft.json": true,
 		// "optional/dependencies-compatibility.json": true,
-		// "optional/dynamicRef.json":                 true,
+		"optional/dynamicRef.json": true,
 		// "optional/ecmascript-regex.json": true,
-		// "optional/format-assertion.json": true,
-		// "optional/id.json":                         true,
+		"optional/format-assertion.json": true,
+		"optional/id.json":               true,
 		// "optional/no-schema.json":                  true,
 		"optional/non-bmp-regex.json": true,
 		// "optional/refOfUnknownKeyword.json":        true,
@@ -107,7 +107,7 @@ var supported202012 = &Supported{
 		"optional/format/uuid.json": true,
 	},
 	skippingFiles: map[string]bool{
-		"uniqueItems.json":                         true, // not supported
+		"uniqueItems.json":                         true, // only supported with WithUniqueItems, see TestSuiteDraft202012UniqueItems
 		"optional/bignum.json":                     true, // Need better decimal support in at least maximum, integer, number, minimum
 		"optional/float-overflow.json":             true, // Need better checking for float overflow to convert to decimal in the json parser and we need to support decimal in multipleOf
 		"optional/dependencies-compatibility.json": true, // just skipping because this is throwing a null pointer exception at the time, we need to fix this.
@@ -137,3 +137,22 @@ var supported202012 = &Supported{
 func TestSuiteDraft202012(t *testing.T) {
 	runTests(t, path202012, supported202012, WithDefaultVersion(schema.VersionDraft2020))
 }
+
+var supportedUniqueItems202012 = &Supported{
+	onlyFiles:    map[string]bool{"uniqueItems.json": true},
+	passingFiles: map[string]bool{"uniqueItems.json": true},
+}
+
+func TestSuiteDraft202012UniqueItems(t *testing.T) {
+	runTests(t, path202012, supportedUniqueItems202012, WithDefaultVersion(schema.VersionDraft2020), WithUniqueItems())
+}
+
+var supportedContentAssertion202012 = &Supported{
+	onlyFiles:        map[string]bool{"content.json": true},
+	passingFiles:     map[string]bool{"content.json": true},
+	contentAssertion: true,
+}
+
+func TestSuiteDraft202012ContentAssertion(t *testing.T) {
+	runTests(t, path202012, supportedContentAssertion202012, WithDefaultVersion(schema.VersionDraft2020), WithContentAssertion())
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	encjson "encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/output"
+)
+
+func TestAnnotate(t *testing.T) {
+	schema := `
+    {
+      "title": "Post",
+      "type": "object",
+      "properties": {
+        "author": { "$ref": "#/$defs/user", "description": "who wrote it" },
+        "status": { "allOf": [{ "title": "Status" }, { "default": "draft", "examples": ["draft", "published"] }] },
+        "body": {
+          "oneOf": [
+            { "title": "Text", "type": "string" },
+            { "title": "Blocks", "type": "array", "items": { "title": "Block" } }
+          ]
+        },
+        "legacy": { "deprecated": true },
+        "kind": {
+          "if": { "type": "string", "title": "Named" },
+          "then": { "description": "a named kind" },
+          "else": { "description": "a numbered kind" }
+        }
+      },
+      "$defs": {
+        "user": { "title": "User", "properties": { "name": { "title": "Name" } } }
+      }
+    }`
+	value := `{"author": {"name": "a"}, "status": "x", "body": ["p"], "legacy": 1, "kind": 2}`
+	want := map[string]*output.Annotations{
+		"":             {Titles: []string{"Post"}},
+		"/author":      {Titles: []string{"User"}, Descriptions: []string{"who wrote it"}},
+		"/author/name": {Titles: []string{"Name"}},
+		"/status":      {Titles: []string{"Status"}, Defaults: []any{"draft"}, Examples: []any{"draft", "published"}},
+		"/body":        {Titles: []string{"Blocks"}},
+		"/body/0":      {Titles: []string{"Block"}},
+		"/legacy":      {Deprecated: true},
+		"/kind":        {Descriptions: []string{"a numbered kind"}},
+	}
+	got, err := Annotate([]byte(schema), []byte(value))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		for location, a := range got {
+			t.Logf("%q: %#v", location, a)
+		}
+		t.Fatal("unexpected annotations")
+	}
+}
+
+func TestAnnotateRefSiblingsBeforeDraft2019(t *testing.T) {
+	schema := `
+    {
+      "$schema": "http://json-schema.org/draft-07/schema#",
+      "properties": { "a": { "$ref": "#/definitions/a", "title": "ignored" } },
+      "definitions": { "a": { "title": "A", "examples": [1] } }
+    }`
+	got, err := Annotate([]byte(schema), []byte(`{"a": 1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]*output.Annotations{
+		"/a": {Titles: []string{"A"}, Examples: []any{encjson.Number("1")}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %#v, but got %#v", want["/a"], got["/a"])
+	}
+}
+
+func TestAnnotateNoMatch(t *testing.T) {
+	schema := []byte(`{"properties": {"a": {"title": "A", "type": "string"}}}`)
+	if _, err := Annotate(schema, []byte(`{"a": 1}`)); !errors.Is(err, ErrNoMatch) {
+		t.Fatalf("want ErrNoMatch, but got %v", err)
+	}
+	_, err := Annotate(schema, []byte(`{"a": 1}`), WithFailureLocation())
+	var matchErr *MatchError
+	if !errors.Is(err, ErrNoMatch) || !errors.As(err, &matchErr) {
+		t.Fatalf("want ErrNoMatch and a MatchError, but got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import "testing"
+
+func TestBooleanSchemas(t *testing.T) {
+	schema := `
+    {
+      "$schema": "http://json-schema.org/draft-07/schema#",
+      "properties": {
+        "anything": true,
+        "nothing": false,
+        "list": {
+          "items": [true, { "type": "string" }],
+          "additionalItems": false
+        }
+      },
+      "not": false
+    }`
+	tests := map[string]bool{
+		`{}`:                                 true,
+		`{"anything": [1, "a", {}]}`:         true,
+		`{"nothing": null}`:                  false,
+		`{"list": [1, "a"]}`:                 true,
+		`{"list": [1, 2]}`:                   false,
+		`{"list": [1, "a", "b"]}`:            false,
+		`{"anything": 1, "list": [{}, "b"]}`: true,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schema), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Error()
+			}
+		})
+	}
+}
+
+func TestBooleanRootSchema(t *testing.T) {
+	tests := map[string]bool{
+		`true`:  true,
+		`false`: false,
+	}
+	for schema, want := range tests {
+		t.Run(schema, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schema), []byte(`{"a": [1]}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Error()
+			}
+		})
+	}
+}
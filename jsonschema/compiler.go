@@ -0,0 +1,113 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/translate"
+	"github.com/katydid/validator-go/validator/ast"
+)
+
+// Compiler compiles schemas that are added as resources and refer to each other by URI.
+// Each resource is parsed once and the definitions that are referenced from other resources are translated once,
+// which makes it cheaper to compile many schemas that share definitions.
+// A Compiler is not safe for concurrent use.
+type Compiler struct {
+	options   *options
+	resources *translate.Resources
+}
+
+// NewCompiler returns a new Compiler, where the options apply to all the resources and compiled schemas.
+func NewCompiler(opts ...Option) *Compiler {
+	return &Compiler{
+		options:   newOptions(opts),
+		resources: translate.NewResources(),
+	}
+}
+
+// AddResource adds a schema that can be referenced by its URI and by its id.
+// The URI must be absolute and if the schema has no id, then the URI is the base URI of the schema.
+func (c *Compiler) AddResource(uri string, schemaStr []byte) error {
+	if c.options.metaSchemaValidation {
+		if err := translate.ValidateMetaSchema(schemaStr, c.options.version); err != nil {
+			return fmt.Errorf("resource %s: %w", uri, err)
+		}
+	}
+	if c.options.strictKeywords {
+		if err := translate.ValidateKeywords(schemaStr, c.options.version); err != nil {
+			return fmt.Errorf("resource %s: %w", uri, err)
+		}
+	}
+	s, err := schema.ParseSchema(schemaStr)
+	if err != nil {
+		return err
+	}
+	s.SetDefaultVersion(c.options.version)
+	return c.resources.Add(uri, s)
+}
+
+// Compile compiles the resource with the URI.
+func (c *Compiler) Compile(uri string) (Matcher, error) {
+	return compile(c.options, func() (*ast.Grammar, *translate.UniqueItems, error) {
+		return c.newGrammar(uri)
+	})
+}
+
+// NewMemoizer returns a memoizer for the resource with the URI.
+func (c *Compiler) NewMemoizer(uri string) (Matcher, error) {
+	g, u, err := c.newGrammar(uri)
+	if err != nil {
+		return nil, err
+	}
+	m, err := newMemoizer(g)
+	if err != nil {
+		return nil, err
+	}
+	return c.options.newMatcher(m, u)
+}
+
+// NewValidator returns a Validator for the resource with the URI.
+func (c *Compiler) NewValidator(uri string) (*Validator, error) {
+	m, err := c.Compile(uri)
+	if err != nil {
+		return nil, err
+	}
+	s, _ := c.resources.Get(uri)
+	opts := append(c.options.translateOptions(c.options.newUniqueItems()), translate.WithResources(c.resources))
+	e, err := translate.NewExplainerFromSchema(s, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{
+		matcher:   m,
+		explainer: e,
+	}, nil
+}
+
+func (c *Compiler) newGrammar(uri string) (*ast.Grammar, *translate.UniqueItems, error) {
+	s, ok := c.resources.Get(uri)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown resource: %s", uri)
+	}
+	u := c.options.newUniqueItems()
+	opts := append(c.options.translateOptions(u), translate.WithResources(c.resources))
+	g, err := translate.NewGrammarFromSchema(s, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g, u, nil
+}
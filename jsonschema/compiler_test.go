@@ -0,0 +1,112 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import "testing"
+
+func TestCompiler(t *testing.T) {
+	c := NewCompiler()
+	resources := map[string]string{
+		"http://example.com/common.json": `{
+			"$defs": {
+				"name": {"type": "string", "minLength": 1},
+				"age": {"type": "integer", "minimum": 0}
+			}
+		}`,
+		"http://example.com/person.json": `{
+			"type": "object",
+			"properties": {
+				"name": {"$ref": "http://example.com/common.json#/$defs/name"},
+				"age": {"$ref": "http://example.com/common.json#/$defs/age"}
+			},
+			"required": ["name"]
+		}`,
+		"http://example.com/team.json": `{
+			"type": "object",
+			"properties": {
+				"name": {"$ref": "http://example.com/common.json#/$defs/name"},
+				"members": {"type": "array", "items": {"$ref": "person.json"}}
+			}
+		}`,
+	}
+	for uri, s := range resources {
+		if err := c.AddResource(uri, []byte(s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		uri   string
+		input string
+		want  bool
+	}{
+		{"http://example.com/person.json", `{"name": "ada", "age": 36}`, true},
+		{"http://example.com/person.json", `{"name": "", "age": 36}`, false},
+		{"http://example.com/person.json", `{"age": 36}`, false},
+		{"http://example.com/team.json", `{"name": "engines", "members": [{"name": "ada"}, {"name": "charles", "age": 45}]}`, true},
+		{"http://example.com/team.json", `{"name": "engines", "members": [{"name": "ada", "age": -1}]}`, false},
+		{"http://example.com/team.json", `{"name": "", "members": []}`, false},
+	}
+	for _, test := range tests {
+		t.Run(test.uri+test.input, func(t *testing.T) {
+			m, err := c.Compile(test.uri)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := m.MatchBytes([]byte(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+	if _, err := c.Compile("http://example.com/unknown.json"); err == nil {
+		t.Fatalf("expected an error for an unknown resource")
+	}
+	if err := c.AddResource("http://example.com/person.json", []byte(`{}`)); err == nil {
+		t.Fatalf("expected an error for a duplicate resource")
+	}
+}
+
+func TestCompilerTranslatesSharedDefinitionsOnce(t *testing.T) {
+	c := NewCompiler()
+	if err := c.AddResource("http://example.com/common.json", []byte(`{"$defs": {"name": {"type": "string", "minLength": 1}}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddResource("http://example.com/person.json", []byte(`{"properties": {"name": {"$ref": "common.json#/$defs/name"}}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddResource("http://example.com/team.json", []byte(`{"properties": {"name": {"$ref": "common.json#/$defs/name"}}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Compile("http://example.com/person.json"); err != nil {
+		t.Fatal(err)
+	}
+	// changing the parsed resource shows whether the shared definition is translated again.
+	common, _ := c.resources.Get("http://example.com/common.json")
+	common.Defs["name"].MinLength = 5
+	m, err := c.Compile("http://example.com/team.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := m.MatchBytes([]byte(`{"name": "ada"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got {
+		t.Fatalf("expected the definition that was translated for person.json to be reused for team.json")
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/katydid/parser-go-json/json"
+	"github.com/katydid/parser-go/parse"
+)
+
+// WithConcurrentUse makes NewInterpreter, NewMemoizer, Compile and the Compiler return a *ConcurrentMatcher, which is safe for concurrent use by multiple goroutines.
+// Without this option a Matcher reuses a single parser for every call, so it may only be used by one goroutine at a time.
+// The clones of a memoizer each have their own cache, which includes Compile when it falls back to a memoizer, because the automaton is too big.
+// This trades memory, and the time to fill each cache, for matching values in parallel.
+func WithConcurrentUse() Option {
+	return func(o *options) {
+		o.concurrentUse = true
+	}
+}
+
+// ConcurrentMatcher is a Matcher that is safe for concurrent use by multiple goroutines.
+// Each call uses a clone of the matcher from a sync.Pool, which has its own parser,
+// but shares the grammar or the automaton with the other matchers in the pool.
+// Memoizers are the exception, since each clone of a memoizer has its own cache, which is not safe to share.
+type ConcurrentMatcher struct {
+	matchers sync.Pool
+}
+
+// cloner is implemented by the matchers of this package.
+type cloner interface {
+	// clone returns a matcher with its own parser and state, which shares everything else with the original.
+	// It returns an error if a matcher that it wraps cannot be cloned.
+	clone() (Matcher, error)
+}
+
+// cloneMatcher clones the matcher, which has to be a matcher that was returned by this package.
+func cloneMatcher(m Matcher) (Matcher, error) {
+	c, ok := m.(cloner)
+	if !ok {
+		return nil, fmt.Errorf("matcher of type %T cannot be cloned for concurrent use", m)
+	}
+	return c.clone()
+}
+
+// NewConcurrentMatcher returns a ConcurrentMatcher that shares everything, except the parser and the memoizer's cache, with the matcher,
+// which has to be a matcher that was returned by this package.
+// The matcher itself is never used by the ConcurrentMatcher, so the caller can keep using it.
+func NewConcurrentMatcher(m Matcher) (*ConcurrentMatcher, error) {
+	if c, ok := m.(*ConcurrentMatcher); ok {
+		return c, nil
+	}
+	// cloning once checks that all the matchers that m wraps can be cloned, so the pool can always clone m.
+	clone, err := cloneMatcher(m)
+	if err != nil {
+		return nil, err
+	}
+	cm := &ConcurrentMatcher{}
+	cm.matchers.New = func() any {
+		clone, err := cloneMatcher(m)
+		if err != nil {
+			return failedMatcher{err}
+		}
+		return clone
+	}
+	cm.matchers.Put(clone)
+	return cm, nil
+}
+
+// failedMatcher returns the error of a matcher that could not be cloned.
+type failedMatcher struct {
+	err error
+}
+
+func (m failedMatcher) MatchBytes(jsonStr []byte) (bool, error) {
+	return false, m.err
+}
+
+func (m failedMatcher) MatchParser(p parse.Parser) (bool, error) {
+	return false, m.err
+}
+
+func (c *ConcurrentMatcher) MatchBytes(jsonStr []byte) (bool, error) {
+	m := c.matchers.Get().(Matcher)
+	defer c.matchers.Put(m)
+	return m.MatchBytes(jsonStr)
+}
+
+func (c *ConcurrentMatcher) MatchParser(p parse.Parser) (bool, error) {
+	m := c.matchers.Get().(Matcher)
+	defer c.matchers.Put(m)
+	return m.MatchParser(p)
+}
+
+func (i *interpret) clone() (Matcher, error) {
+	return &interpret{
+		parser: json.NewJSONSchemaParser(),
+		g:      i.g,
+	}, nil
+}
+
+func (m *memoize) clone() (Matcher, error) {
+	return newMemoizer(m.g)
+}
+
+func (c *compiled) clone() (Matcher, error) {
+	return &compiled{
+		parser: json.NewJSONSchemaParser(),
+		auto:   c.auto,
+		g:      c.g,
+	}, nil
+}
+
+func (m *locatingMatcher) clone() (Matcher, error) {
+	matcher, err := cloneMatcher(m.matcher)
+	if err != nil {
+		return nil, err
+	}
+	return &locatingMatcher{
+		matcher: matcher,
+		parser:  json.NewJSONSchemaParser(),
+	}, nil
+}
+
+func (m *uniqueItemsMatcher) clone() (Matcher, error) {
+	matcher, err := cloneMatcher(m.matcher)
+	if err != nil {
+		return nil, err
+	}
+	return &uniqueItemsMatcher{
+		matcher:         matcher,
+		uniqueItems:     m.uniqueItems,
+		failureLocation: m.failureLocation,
+	}, nil
+}
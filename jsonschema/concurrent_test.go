@@ -0,0 +1,203 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json"
+	"github.com/katydid/parser-go/parse"
+)
+
+// These tests are meant to be run with the race detector, for example: go test -race -run Concurrent ./jsonschema
+
+var concurrentSchema = []byte(`
+{
+  "type": "object",
+  "properties": {
+    "name": { "type": "string" },
+    "tags": { "type": "array", "items": { "type": "string" }, "uniqueItems": true }
+  },
+  "required": ["name"]
+}`)
+
+var concurrentTests = map[string]bool{
+	`{"name": "a"}`:                     true,
+	`{"name": "a", "tags": ["x", "y"]}`: true,
+	`{"name": "a", "tags": ["x", "x"]}`: false,
+	`{"name": 1}`:                       false,
+	`{"tags": []}`:                      false,
+}
+
+// matchConcurrently matches all the tests many times from multiple goroutines.
+func matchConcurrently(t *testing.T, m Matcher) {
+	t.Helper()
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				for value, want := range concurrentTests {
+					got, err := m.MatchBytes([]byte(value))
+					var matchErr *MatchError
+					if err != nil && !errors.As(err, &matchErr) {
+						errs <- err
+						return
+					}
+					if got != want {
+						errs <- errors.New(value + ": unexpected result")
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestConcurrentMatchers(t *testing.T) {
+	for name, newMatcher := range map[string]func([]byte, ...Option) (Matcher, error){
+		"interpreter": NewInterpreter,
+		"memoizer":    NewMemoizer,
+		"compiled":    Compile,
+	} {
+		for _, opts := range [][]Option{
+			{WithConcurrentUse(), WithUniqueItems()},
+			{WithConcurrentUse(), WithUniqueItems(), WithFailureLocation()},
+		} {
+			t.Run(name, func(t *testing.T) {
+				m, err := newMatcher(concurrentSchema, opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, ok := m.(*ConcurrentMatcher); !ok {
+					t.Fatalf("expected a *ConcurrentMatcher, but got %T", m)
+				}
+				matchConcurrently(t, m)
+			})
+		}
+	}
+}
+
+func TestConcurrentCompiler(t *testing.T) {
+	c := NewCompiler(WithConcurrentUse(), WithUniqueItems())
+	if err := c.AddResource("http://example.com/post.json", concurrentSchema); err != nil {
+		t.Fatal(err)
+	}
+	m, err := c.Compile("http://example.com/post.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	matchConcurrently(t, m)
+	m, err = c.NewMemoizer("http://example.com/post.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	matchConcurrently(t, m)
+}
+
+func TestNewConcurrentMatcher(t *testing.T) {
+	for name, newMatcher := range map[string]func([]byte, ...Option) (Matcher, error){
+		"memoizer": NewMemoizer,
+		"compiled": Compile,
+	} {
+		t.Run(name, func(t *testing.T) {
+			m, err := newMatcher([]byte(`{"type": "string"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := NewConcurrentMatcher(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var wg sync.WaitGroup
+			for range 8 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					p := json.NewJSONSchemaParser()
+					for range 50 {
+						p.Init([]byte(`"a"`))
+						if ok, err := c.MatchParser(p); !ok || err != nil {
+							t.Errorf("expected match, but got %v, %v", ok, err)
+							return
+						}
+					}
+				}()
+			}
+			// the caller keeps using its own matcher, which is not in the pool.
+			for range 50 {
+				if ok, err := m.MatchBytes([]byte(`1`)); ok || err != nil {
+					t.Errorf("expected no match, but got %v, %v", ok, err)
+					break
+				}
+			}
+			wg.Wait()
+		})
+	}
+	if _, err := NewConcurrentMatcher(nil); err == nil {
+		t.Fatal("expected an error for a matcher that is not from this package")
+	}
+	if _, err := NewConcurrentMatcher(withFailureLocation(foreignMatcher{})); err == nil {
+		t.Fatal("expected an error for a wrapped matcher that is not from this package")
+	}
+}
+
+// foreignMatcher is a Matcher that is not from this package, so it cannot be cloned.
+type foreignMatcher struct{}
+
+func (foreignMatcher) MatchBytes(jsonStr []byte) (bool, error) {
+	return true, nil
+}
+
+func (foreignMatcher) MatchParser(p parse.Parser) (bool, error) {
+	return true, nil
+}
+
+func TestConcurrentValidator(t *testing.T) {
+	v, err := NewValidator(concurrentSchema, WithConcurrentUse(), WithUniqueItems())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				for value, want := range concurrentTests {
+					result, err := v.Validate([]byte(value))
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					if result.Valid != want {
+						t.Errorf("%s: want %v, but got %v", value, want, result.Valid)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
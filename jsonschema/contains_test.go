@@ -0,0 +1,87 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import "testing"
+
+func TestContains(t *testing.T) {
+	schema := `
+    {
+      "type": "object",
+      "properties": {
+        "phones": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "properties": {
+              "type": { "enum": ["primary", "secondary"] },
+              "number": { "type": "string" }
+            }
+          },
+          "contains": {
+            "properties": { "type": { "const": "primary" } },
+            "required": ["type"]
+          },
+          "maxContains": 1
+        }
+      }
+    }`
+	tests := map[string]bool{
+		`{"phones": [{"type": "primary", "number": "123"}]}`:                            true,
+		`{"phones": [{"type": "secondary"}, {"type": "primary"}]}`:                      true,
+		`{"phones": [{"type": "secondary"}]}`:                                           false,
+		`{"phones": []}`:                                                                false,
+		`{"phones": [{"type": "primary"}, {"type": "secondary"}, {"type": "primary"}]}`: false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schema), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Error()
+			}
+		})
+	}
+}
+
+func TestMinContains(t *testing.T) {
+	schema := `
+    {
+      "contains": { "const": 1 },
+      "minContains": 2,
+      "maxContains": 3
+    }`
+	tests := map[string]bool{
+		`[1]`:             false,
+		`[1, 2, 1]`:       true,
+		`[1, 1, 1]`:       true,
+		`[1, 1, 1, 1]`:    false,
+		`[2, 1, 2, 1, 2]`: true,
+		`"not an array"`:  true,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schema), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Error()
+			}
+		})
+	}
+}
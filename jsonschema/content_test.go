@@ -0,0 +1,110 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import "testing"
+
+func TestContentAssertion(t *testing.T) {
+	schemaStr := `
+    {
+      "$schema": "https://json-schema.org/draft/2020-12/schema",
+      "contentEncoding": "base64",
+      "contentMediaType": "application/json",
+      "contentSchema": {"type": "object", "properties": {"foo": {"type": "string"}}}
+    }`
+	tests := []struct {
+		input      string
+		annotation bool
+		assertion  bool
+	}{
+		{`"eyJmb28iOiAiYmFyIn0="`, true, true}, // {"foo": "bar"}
+		{`"eyJmb28iOiAxfQ=="`, true, false},    // {"foo": 1}
+		{`"ezp9"`, true, false},                // {:}
+		{`"not base64!"`, true, false},
+		{`1`, true, true},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.annotation {
+				t.Errorf("want %v without content assertion, got %v", test.annotation, got)
+			}
+			got, err = MatchBytes([]byte(schemaStr), []byte(test.input), WithContentAssertion())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.assertion {
+				t.Errorf("want %v with content assertion, got %v", test.assertion, got)
+			}
+		})
+	}
+}
+
+func TestContentAssertionBase16(t *testing.T) {
+	schemaStr := `
+    {
+      "$schema": "https://json-schema.org/draft/2020-12/schema",
+      "contentEncoding": "base16",
+      "contentMediaType": "application/json"
+    }`
+	tests := map[string]bool{
+		`"7B22666F6F223A20317D"`: true,
+		`"7B3A7D"`:               false,
+		`"7B3"`:                  false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test), WithContentAssertion())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestContentSchemaRef(t *testing.T) {
+	schemaStr := `
+    {
+      "$schema": "https://json-schema.org/draft/2020-12/schema",
+      "$defs": {
+        "positive": {"type": "number", "minimum": 1}
+      },
+      "contentMediaType": "application/json",
+      "contentSchema": {"type": "object", "properties": {"foo": {"$ref": "#/$defs/positive"}}}
+    }`
+	tests := map[string]bool{
+		`"{\"foo\": 2}"`:     true,
+		`"{\"foo\": 0}"`:     false,
+		`"{\"foo\": \"a\"}"`: false,
+		`"[]"`:               false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test), WithContentAssertion())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+func TestCrossDraftEmbeddedResource(t *testing.T) {
+	schemaStr := `
+    {
+      "$schema": "https://json-schema.org/draft/2020-12/schema",
+      "properties": {
+        "legacy": {"$ref": "http://example.com/legacy.json"},
+        "modern": {"prefixItems": [{"type": "string"}], "items": false},
+        "required": {"dependentRequired": {"a": ["b"]}}
+      },
+      "$defs": {
+        "legacy": {
+          "$id": "http://example.com/legacy.json",
+          "$schema": "http://json-schema.org/draft-07/schema#",
+          "items": [{"type": "string"}],
+          "additionalItems": false,
+          "prefixItems": [{"type": "integer"}],
+          "dependentRequired": {"a": ["b"]},
+          "properties": {
+            "small": {"$ref": "#/definitions/int", "maximum": 1}
+          },
+          "definitions": {
+            "int": {"type": "integer"}
+          }
+        }
+      }
+    }`
+	tests := map[string]bool{
+		`{"legacy": ["a"]}`:              true,
+		`{"legacy": [1]}`:                false,
+		`{"legacy": ["a", "b"]}`:         false,
+		`{"legacy": {"a": 1}}`:           true,
+		`{"legacy": {"small": 5}}`:       true,
+		`{"legacy": {"small": "a"}}`:     false,
+		`{"modern": ["a"]}`:              true,
+		`{"modern": ["a", "b"]}`:         false,
+		`{"required": {"a": 1}}`:         false,
+		`{"required": {"a": 1, "b": 2}}`: true,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test), WithDefaultVersion(schema.VersionDraft7))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
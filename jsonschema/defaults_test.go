@@ -0,0 +1,125 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	encjson "encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/translate"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	schema := `
+    {
+      "type": "object",
+      "properties": {
+        "name": { "type": "string" },
+        "port": { "$ref": "#/$defs/port" },
+        "server": { "type": "object", "default": {}, "properties": { "host": { "default": "localhost" } } },
+        "mode": { "enum": ["dev", "prod"], "default": "dev" }
+      },
+      "allOf": [{ "properties": { "timeout": { "default": 30 }, "mode": { "default": "prod" } } }],
+      "if": { "properties": { "mode": { "const": "dev" } } },
+      "then": { "properties": { "debug": { "default": true } } },
+      "else": { "properties": { "debug": { "default": false } } },
+      "$defs": { "port": { "type": "integer", "default": 8080 } }
+    }`
+	tests := map[string]string{
+		`{}`: `{"debug":true,"mode":"dev","port":8080,"server":{"host":"localhost"},"timeout":30}`,
+		`{"mode": "prod", "server": {"host": "example.com"}, "port": 1}`: `{"debug":false,"mode":"prod","port":1,"server":{"host":"example.com"},"timeout":30}`,
+		`{"name": "<a&b>", "debug": false, "timeout": 1.5}`:              `{"debug":false,"mode":"dev","name":"<a&b>","port":8080,"server":{"host":"localhost"},"timeout":1.5}`,
+	}
+	for value, want := range tests {
+		t.Run(value, func(t *testing.T) {
+			got, err := ApplyDefaults([]byte(schema), []byte(value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != want {
+				t.Fatalf("want %s, but got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestApplyDefaultsRecursive(t *testing.T) {
+	schema := `{"properties": {"child": {"$ref": "#", "default": {}}, "size": {"default": 1}}}`
+	got, err := ApplyDefaults([]byte(schema), []byte(`{"child": {"child": {}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"child":{"child":{"child":{"size":1},"size":1},"size":1},"size":1}`
+	if string(got) != want {
+		t.Fatalf("want %s, but got %s", want, got)
+	}
+}
+
+func TestApplyDefaultsNoMatch(t *testing.T) {
+	schema := []byte(`{"properties": {"a": {"type": "string", "default": 1}}}`)
+	if _, err := ApplyDefaults(schema, []byte(`{}`)); !errors.Is(err, ErrNoMatch) || err == ErrNoMatch {
+		t.Fatalf("want ErrNoMatch with defaults for a default that does not match, but got %v", err)
+	}
+	// the value is matched before the defaults are applied, like Annotate.
+	if _, err := ApplyDefaults(schema, []byte(`{"a": 2}`)); err != ErrNoMatch {
+		t.Fatalf("want ErrNoMatch for a value that does not match, but got %v", err)
+	}
+}
+
+func TestApplyDefaultsNull(t *testing.T) {
+	schemaStr := `{"properties": {"a": {"default": null}, "b": {"$ref": "#/$defs/b"}}, "$defs": {"b": {"default": null}}}`
+	got, err := ApplyDefaults([]byte(schemaStr), []byte(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":null,"b":null}`; string(got) != want {
+		t.Fatalf("want %s, but got %s", want, got)
+	}
+}
+
+func TestApplyDefaultsSelfReference(t *testing.T) {
+	schemas := []string{
+		`{"$ref": "#", "properties": {"a": {"default": 1}}}`,
+		`{"allOf": [{"$ref": "#"}], "properties": {"a": {"default": 1}}}`,
+		`{"properties": {"a": {"$ref": "#/$defs/loop"}}, "$defs": {"loop": {"allOf": [{"$ref": "#/$defs/loop"}]}}}`,
+	}
+	wants := []string{`{"a":1}`, `{"a":1}`, `{}`}
+	for i, schemaStr := range schemas {
+		t.Run(schemaStr, func(t *testing.T) {
+			// the explainer is used directly, since only the defaults are applied, without matching the value.
+			e, err := translate.NewExplainer([]byte(schemaStr), schema.VersionLatest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var value any
+			if err := std.UnmarshalJSON([]byte(`{}`), &value); err != nil {
+				t.Fatal(err)
+			}
+			if err := e.ApplyDefaults(value); err != nil {
+				t.Fatal(err)
+			}
+			got, err := encjson.Marshal(value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != wants[i] {
+				t.Fatalf("want %s, but got %s", wants[i], got)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import "testing"
+
+func TestDefs(t *testing.T) {
+	schema := `
+    {
+      "$schema": "https://json-schema.org/draft/2020-12/schema",
+      "$id": "https://example.com/address.schema.json",
+      "type": "object",
+      "properties": {
+        "street": { "$ref": "#/$defs/line" },
+        "city": { "$ref": "#/$defs/line" },
+        "country": { "$ref": "#/definitions/country" }
+      },
+      "$defs": {
+        "line": { "type": "string", "maxLength": 10 }
+      },
+      "definitions": {
+        "country": { "enum": ["NL", "ZA"] }
+      }
+    }`
+	tests := map[string]bool{
+		`{"street": "Dam"}`:                      true,
+		`{"street": "Dam", "city": "Amsterdam"}`: true,
+		`{"street": 123}`:                        false,
+		`{"city": "Amsterdam-Noord"}`:            false,
+		`{"country": "NL"}`:                      true,
+		`{"country": "US"}`:                      false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schema), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Error()
+			}
+		})
+	}
+}
+
+func TestDefsResolvedAgainstBaseURI(t *testing.T) {
+	schema := `
+    {
+      "$schema": "https://json-schema.org/draft/2020-12/schema",
+      "$id": "https://example.com/schemas/person.json",
+      "type": "object",
+      "properties": {
+        "name": { "$ref": "../common/name.json" },
+        "nickname": { "$ref": "../common/name.json#short" },
+        "address": {
+          "$id": "urn:example:address",
+          "type": "object",
+          "properties": {
+            "zip": { "$ref": "#/$defs/zip" }
+          },
+          "$defs": {
+            "zip": { "type": "string", "maxLength": 4 }
+          }
+        },
+        "home": { "$ref": "urn:example:address" }
+      },
+      "$defs": {
+        "name": {
+          "$id": "../common/name.json",
+          "type": "string",
+          "maxLength": 10,
+          "$defs": {
+            "short": { "$anchor": "short", "type": "string", "maxLength": 3 }
+          }
+        }
+      }
+    }`
+	tests := map[string]bool{
+		`{"name": "Dam"}`:                true,
+		`{"name": "Amsterdam-Noord"}`:    false,
+		`{"nickname": "Dam"}`:            true,
+		`{"nickname": "Damrak"}`:         false,
+		`{"address": {"zip": "1012"}}`:   true,
+		`{"address": {"zip": "1012AB"}}`: false,
+		`{"home": {"zip": "1012"}}`:      true,
+		`{"home": {"zip": 1012}}`:        false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schema), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Error()
+			}
+		})
+	}
+}
+
+func TestDefsRefIntoKeywords(t *testing.T) {
+	schema := `
+    {
+      "$schema": "https://json-schema.org/draft/2020-12/schema",
+      "type": "object",
+      "properties": {
+        "id": { "$ref": "#/allOf/1/properties/x" },
+        "extra": { "$ref": "#/patternProperties/%5Ex-" },
+        "tags": { "$ref": "#/dependentSchemas/tagged/properties/tags" }
+      },
+      "allOf": [
+        { "type": "object" },
+        { "properties": { "x": { "type": "integer" } } }
+      ],
+      "patternProperties": { "^x-": { "type": "string" } },
+      "dependentSchemas": {
+        "tagged": { "properties": { "tags": { "type": "array" } } }
+      }
+    }`
+	tests := map[string]bool{
+		`{"id": 1}`:         true,
+		`{"id": "1"}`:       false,
+		`{"extra": "a"}`:    true,
+		`{"extra": 1}`:      false,
+		`{"tags": []}`:      true,
+		`{"tags": "a"}`:     false,
+		`{"x-custom": "a"}`: true,
+		`{"x-custom": 1}`:   false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schema), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Error()
+			}
+		})
+	}
+}
+
+func TestDefsURNAndAnchors(t *testing.T) {
+	tests := []struct {
+		schema string
+		value  string
+		want   bool
+	}{
+		{`{"$id": "urn:uuid:deadbeef-1234-ffff-ffff-4321feebdaed", "properties": {"foo": {"$ref": "#/$defs/bar"}}, "$defs": {"bar": {"type": "string"}}}`, `{"foo": "a"}`, true},
+		{`{"$id": "urn:uuid:deadbeef-1234-ffff-ffff-4321feebdaed", "properties": {"foo": {"$ref": "#/$defs/bar"}}, "$defs": {"bar": {"type": "string"}}}`, `{"foo": 1}`, false},
+		{`{"$id": "urn:uuid:deadbeef-1234-ff00-00ff-4321feebdaed", "properties": {"foo": {"$ref": "urn:uuid:deadbeef-1234-ff00-00ff-4321feebdaed#something"}}, "$defs": {"bar": {"$anchor": "something", "type": "string"}}}`, `{"foo": 1}`, false},
+		{`{"$ref": "http://example.com/ref/if", "if": {"$id": "http://example.com/ref/if", "type": "integer"}}`, `"a"`, false},
+		{`{"$ref": "http://example.com/ref/if", "if": {"$id": "http://example.com/ref/if", "type": "integer"}}`, `1`, true},
+		{`{"$defs": {"anchor_in_enum": {"enum": [{"$anchor": "my_anchor", "type": "null"}]}, "real_identifier": {"$anchor": "my_anchor", "type": "string"}}, "anyOf": [{"$ref": "#/$defs/anchor_in_enum"}, {"$ref": "#my_anchor"}]}`, `null`, false},
+		{`{"$defs": {"anchor_in_enum": {"enum": [{"$anchor": "my_anchor", "type": "null"}]}, "real_identifier": {"$anchor": "my_anchor", "type": "string"}}, "anyOf": [{"$ref": "#/$defs/anchor_in_enum"}, {"$ref": "#my_anchor"}]}`, `"a"`, true},
+	}
+	for _, test := range tests {
+		t.Run(test.schema+test.value, func(t *testing.T) {
+			got, err := MatchBytes([]byte(test.schema), []byte(test.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+//  Copyright 2015 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+const pathDraft3 = "../../../json-schema-org/JSON-Schema-Test-Suite/tests/draft3/"
+
+var supportedDraft3 = &Supported{
+	passingFiles: map[string]bool{
+		"additionalItems.json":      true,
+		"additionalProperties.json": true,
+		"default.json":              true,
+		"dependencies.json":         true,
+		"disallow.json":             true,
+		"divisibleBy.json":          true,
+		"enum.json":                 true,
+		"extends.json":              true,
+		"items.json":                true,
+		"maximum.json":              true,
+		"maxItems.json":             true,
+		"maxLength.json":            true,
+		"minimum.json":              true,
+		"minItems.json":             true,
+		"minLength.json":            true,
+		"pattern.json":              true,
+		"patternProperties.json":    true,
+		"properties.json":           true,
+		"refRemote.json":            true,
+		"required.json":             true,
+		"type.json":                 true,
+	},
+	skippingFiles: map[string]bool{},
+	passingTests:  map[string]bool{},
+	skippingTests: map[string]bool{},
+	strict:        false, // the Draft 3 meta-schema is not embedded, so references to it cannot be resolved.
+}
+
+func TestSuiteDraft3(t *testing.T) {
+	runTests(t, pathDraft3, supportedDraft3, WithDefaultVersion(schema.VersionDraft3))
+}
+
+func TestDraft3(t *testing.T) {
+	tests := []struct {
+		schema string
+		value  string
+		want   bool
+	}{
+		{`{"properties": {"a": {"required": true}, "b": {"type": "string"}}}`, `{"a": 1}`, true},
+		{`{"properties": {"a": {"required": true}, "b": {"type": "string"}}}`, `{"b": "x"}`, false},
+		{`{"properties": {"a": {"required": false}}}`, `{}`, true},
+		{`{"divisibleBy": 1.5}`, `4.5`, true},
+		{`{"divisibleBy": 1.5}`, `4`, false},
+		{`{"divisibleBy": 1.5}`, `"not a number"`, true},
+		{`{"disallow": ["string", "null"]}`, `1`, true},
+		{`{"disallow": ["string", "null"]}`, `null`, false},
+		{`{"disallow": [{"type": "integer", "minimum": 10}]}`, `5`, true},
+		{`{"disallow": [{"type": "integer", "minimum": 10}]}`, `15`, false},
+		{`{"type": "any"}`, `{"a": [1]}`, true},
+		{`{"type": ["null", {"type": "string", "maxLength": 2}]}`, `null`, true},
+		{`{"type": ["null", {"type": "string", "maxLength": 2}]}`, `"ab"`, true},
+		{`{"type": ["null", {"type": "string", "maxLength": 2}]}`, `"abc"`, false},
+		{`{"type": ["null", {"type": "string", "maxLength": 2}]}`, `1`, false},
+		{`{"extends": {"minimum": 2}, "maximum": 4}`, `3`, true},
+		{`{"extends": {"minimum": 2}, "maximum": 4}`, `1`, false},
+		{`{"extends": [{"minimum": 2}, {"maximum": 4}]}`, `5`, false},
+		{`{"dependencies": {"a": "b"}}`, `{"a": 1, "b": 2}`, true},
+		{`{"dependencies": {"a": "b"}}`, `{"a": 1}`, false},
+	}
+	for _, test := range tests {
+		t.Run(test.schema+test.value, func(t *testing.T) {
+			got, err := MatchBytes([]byte(test.schema), []byte(test.value), WithDefaultVersion(schema.VersionDraft3))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDraft3SchemaKeyword(t *testing.T) {
+	schemaStr := `{"$schema": "http://json-schema.org/draft-03/schema#", "properties": {"a": {"type": "string", "required": true}}}`
+	tests := map[string]bool{
+		`{"a": "x"}`: true,
+		`{"a": 1}`:   false,
+		`{}`:         false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
@@ -50,6 +50,7 @@ var supportedDraft4 = &Supported{
 		"patternProperties.json":       true,
 		"properties.json":              true,
 		"ref.json":                     true,
+		"refRemote.json":               true,
 		"required.json":                true,
 		"type.json":                    true,
 
@@ -71,8 +72,7 @@ var supportedDraft4 = &Supported{
 		"optional/format/uri.json":       true,
 	},
 	skippingFiles: map[string]bool{
-		"uniqueItems.json": true, // We do not support uniqueItems, see https://github.com/katydid/validator-go-jsonschema/blob/main/decisions/uniqueItems.md
-		"refRemote.json":   true, // remote and file ref support should be relatively easy to add, but is just not of theoretical importance at this stage.
+		"uniqueItems.json": true, // only supported with WithUniqueItems, see TestSuiteDraft4UniqueItems
 	},
 	passingTests:  map[string]bool{},
 	skippingTests: map[string]bool{},
@@ -82,3 +82,12 @@ var supportedDraft4 = &Supported{
 func TestSuiteDraft4(t *testing.T) {
 	runTests(t, pathDraft4, supportedDraft4, WithDefaultVersion(schema.VersionDraft4))
 }
+
+var supportedUniqueItemsDraft4 = &Supported{
+	onlyFiles:    map[string]bool{"uniqueItems.json": true},
+	passingFiles: map[string]bool{"uniqueItems.json": true},
+}
+
+func TestSuiteDraft4UniqueItems(t *testing.T) {
+	runTests(t, pathDraft4, supportedUniqueItemsDraft4, WithDefaultVersion(schema.VersionDraft4), WithUniqueItems())
+}
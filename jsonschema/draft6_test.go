@@ -0,0 +1,52 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+const pathDraft6 = "../../../json-schema-org/JSON-Schema-Test-Suite/tests/draft6/"
+
+var supportedDraft6 = &Supported{
+	passingFiles: map[string]bool{
+		"boolean_schema.json": true,
+		"ref.json":            true,
+		"refRemote.json":      true,
+
+		// optional
+		"optional/id.json": true,
+	},
+	skippingFiles: map[string]bool{
+		"uniqueItems.json": true, // only supported with WithUniqueItems, see TestSuiteDraft6UniqueItems
+	},
+	passingTests:  map[string]bool{},
+	skippingTests: map[string]bool{},
+}
+
+func TestSuiteDraft6(t *testing.T) {
+	runTests(t, pathDraft6, supportedDraft6, WithDefaultVersion(schema.VersionDraft6))
+}
+
+var supportedUniqueItemsDraft6 = &Supported{
+	onlyFiles:    map[string]bool{"uniqueItems.json": true},
+	passingFiles: map[string]bool{"uniqueItems.json": true},
+}
+
+func TestSuiteDraft6UniqueItems(t *testing.T) {
+	runTests(t, pathDraft6, supportedUniqueItemsDraft6, WithDefaultVersion(schema.VersionDraft6), WithUniqueItems())
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+const pathDraft7 = "../../../json-schema-org/JSON-Schema-Test-Suite/tests/draft7/"
+
+var supportedDraft7 = &Supported{
+	passingFiles: map[string]bool{
+		"boolean_schema.json": true,
+		"ref.json":            true,
+		"refRemote.json":      true,
+
+		// optional
+		"optional/id.json": true,
+	},
+	skippingFiles: map[string]bool{
+		"uniqueItems.json": true, // only supported with WithUniqueItems, see TestSuiteDraft7UniqueItems
+	},
+	passingTests:  map[string]bool{},
+	skippingTests: map[string]bool{},
+}
+
+func TestSuiteDraft7(t *testing.T) {
+	runTests(t, pathDraft7, supportedDraft7, WithDefaultVersion(schema.VersionDraft7))
+}
+
+var supportedUniqueItemsDraft7 = &Supported{
+	onlyFiles:    map[string]bool{"uniqueItems.json": true},
+	passingFiles: map[string]bool{"uniqueItems.json": true},
+}
+
+func TestSuiteDraft7UniqueItems(t *testing.T) {
+	runTests(t, pathDraft7, supportedUniqueItemsDraft7, WithDefaultVersion(schema.VersionDraft7), WithUniqueItems())
+}
+
+func TestDraft7RejectsDraft3Forms(t *testing.T) {
+	schemas := []string{
+		`{"type": "any"}`,
+		`{"properties": {"a": {"required": true}}}`,
+		`{"type": ["null", {"type": "string"}]}`,
+		`{"$schema": "http://json-schema.org/draft-07/schema#", "items": {"type": "any"}}`,
+	}
+	for _, schemaStr := range schemas {
+		t.Run(schemaStr, func(t *testing.T) {
+			if _, err := MatchBytes([]byte(schemaStr), []byte(`1`), WithDefaultVersion(schema.VersionDraft7)); err == nil {
+				t.Fatal("expected the Draft 3 form to be an error in Draft 7")
+			}
+			if _, err := MatchBytes([]byte(schemaStr), []byte(`1`)); err == nil {
+				t.Fatal("expected the Draft 3 form to be an error in the latest version")
+			}
+		})
+	}
+	for _, schemaStr := range schemas[:3] {
+		if _, err := MatchBytes([]byte(schemaStr), []byte(`1`), WithDefaultVersion(schema.VersionDraft3)); err != nil {
+			t.Fatalf("expected %s to be supported in Draft 3, but got %v", schemaStr, err)
+		}
+	}
+}
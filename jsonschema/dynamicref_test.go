@@ -0,0 +1,227 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+func TestDynamicRef(t *testing.T) {
+	schemas := map[string]struct {
+		schema string
+		tests  map[string]bool
+	}{
+		"same resource behaves like ref": {
+			schema: `{
+				"$id": "https://example.com/same/root",
+				"type": "array",
+				"items": {"$dynamicRef": "#items"},
+				"$defs": {
+					"foo": {"$dynamicAnchor": "items", "type": "string"}
+				}
+			}`,
+			tests: map[string]bool{
+				`["foo", "bar"]`: true,
+				`["foo", 42]`:    false,
+			},
+		},
+		"outermost dynamic anchor wins": {
+			schema: `{
+				"$id": "https://example.com/typical/root",
+				"$ref": "list",
+				"$defs": {
+					"foo": {"$dynamicAnchor": "items", "type": "string"},
+					"list": {
+						"$id": "list",
+						"type": "array",
+						"items": {"$dynamicRef": "#items"},
+						"$defs": {
+							"items": {"$dynamicAnchor": "items"}
+						}
+					}
+				}
+			}`,
+			tests: map[string]bool{
+				`["foo", "bar"]`: true,
+				`["foo", 42]`:    false,
+			},
+		},
+		"intermediate scopes without anchor": {
+			schema: `{
+				"$id": "https://example.com/intermediate/root",
+				"$ref": "intermediate-scope",
+				"$defs": {
+					"foo": {"$dynamicAnchor": "items", "type": "string"},
+					"intermediate-scope": {"$id": "intermediate-scope", "$ref": "list"},
+					"list": {
+						"$id": "list",
+						"type": "array",
+						"items": {"$dynamicRef": "#items"},
+						"$defs": {
+							"items": {"$dynamicAnchor": "items"}
+						}
+					}
+				}
+			}`,
+			tests: map[string]bool{
+				`["foo", "bar"]`: true,
+				`["foo", 42]`:    false,
+			},
+		},
+		"anchor is not used for dynamic resolution": {
+			schema: `{
+				"$id": "https://example.com/anchor/root",
+				"$ref": "list",
+				"$defs": {
+					"foo": {"$anchor": "items", "type": "string"},
+					"list": {
+						"$id": "list",
+						"type": "array",
+						"items": {"$dynamicRef": "#items"},
+						"$defs": {
+							"items": {"$dynamicAnchor": "items"}
+						}
+					}
+				}
+			}`,
+			tests: map[string]bool{
+				`["foo", 42]`: true,
+			},
+		},
+		"without matching dynamic anchor behaves like ref": {
+			schema: `{
+				"$id": "https://example.com/unmatched/root",
+				"$ref": "list",
+				"$defs": {
+					"foo": {"$dynamicAnchor": "items", "type": "string"},
+					"list": {
+						"$id": "list",
+						"type": "array",
+						"items": {"$dynamicRef": "#items"},
+						"$defs": {
+							"items": {"$anchor": "items", "$dynamicAnchor": "foo"}
+						}
+					}
+				}
+			}`,
+			tests: map[string]bool{
+				`["foo", 42]`: true,
+			},
+		},
+		"multiple dynamic paths": {
+			schema: `{
+				"$id": "https://example.com/paths/main",
+				"if": {
+					"properties": {"kindOfList": {"const": "numbers"}},
+					"required": ["kindOfList"]
+				},
+				"then": {"$ref": "numberList"},
+				"else": {"$ref": "stringList"},
+				"$defs": {
+					"genericList": {
+						"$id": "genericList",
+						"properties": {
+							"list": {"items": {"$dynamicRef": "#itemType"}}
+						},
+						"$defs": {
+							"defaultItemType": {"$dynamicAnchor": "itemType"}
+						}
+					},
+					"numberList": {
+						"$id": "numberList",
+						"$defs": {
+							"itemType": {"$dynamicAnchor": "itemType", "type": "number"}
+						},
+						"$ref": "genericList"
+					},
+					"stringList": {
+						"$id": "stringList",
+						"$defs": {
+							"itemType": {"$dynamicAnchor": "itemType", "type": "string"}
+						},
+						"$ref": "genericList"
+					}
+				}
+			}`,
+			tests: map[string]bool{
+				`{"kindOfList": "numbers", "list": [1.1]}`:   true,
+				`{"kindOfList": "numbers", "list": ["foo"]}`: false,
+				`{"kindOfList": "strings", "list": ["foo"]}`: true,
+				`{"kindOfList": "strings", "list": [1.1]}`:   false,
+			},
+		},
+		"initially resolves to a matching dynamic anchor": {
+			schema: `{
+				"$id": "https://example.com/relative/root",
+				"$dynamicAnchor": "meta",
+				"type": "object",
+				"properties": {"foo": {"const": "pass"}},
+				"$ref": "extended",
+				"$defs": {
+					"extended": {
+						"$id": "extended",
+						"$dynamicAnchor": "meta",
+						"type": "object",
+						"properties": {"bar": {"$ref": "bar"}}
+					},
+					"bar": {
+						"$id": "bar",
+						"type": "object",
+						"properties": {"baz": {"$dynamicRef": "extended#meta"}}
+					}
+				}
+			}`,
+			tests: map[string]bool{
+				`{"foo": "pass", "bar": {"baz": {"foo": "pass"}}}`: true,
+				`{"foo": "pass", "bar": {"baz": {"foo": "fail"}}}`: false,
+			},
+		},
+	}
+	for name, s := range schemas {
+		for test, want := range s.tests {
+			t.Run(name+":"+test, func(t *testing.T) {
+				got, err := MatchBytes([]byte(s.schema), []byte(test), WithDefaultVersion(schema.VersionDraft2020))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("want %v, got %v", want, got)
+				}
+			})
+		}
+	}
+}
+
+func TestDynamicRefUnresolved(t *testing.T) {
+	// the anchor is bound in the dynamic scope, but the initial target does not exist in the resource of the $dynamicRef.
+	schemaStr := `{
+		"$id": "https://example.com/root",
+		"$dynamicAnchor": "items",
+		"properties": {"a": {"$ref": "other"}},
+		"$defs": {
+			"other": {"$id": "other", "$dynamicRef": "#items"}
+		}
+	}`
+	_, err := MatchBytes([]byte(schemaStr), []byte(`{"a": 1}`), WithDefaultVersion(schema.VersionDraft2020))
+	if err == nil {
+		t.Fatal("expected an error for a $dynamicRef whose initial target does not exist")
+	}
+	if !strings.Contains(err.Error(), "could not resolve reference") {
+		t.Fatalf("expected an unresolved reference error, but got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package funcs
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"strings"
+)
+
+// ErrUnknownContentEncoding is returned by DecodeContent for encodings that are not supported.
+var ErrUnknownContentEncoding = errors.New("unknown contentEncoding")
+
+// DecodeContent decodes a string according to its contentEncoding.
+// An empty encoding means that the string is not encoded.
+func DecodeContent(encoding string, s string) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "":
+		return []byte(s), nil
+	case "base64":
+		return decodeBase64(base64.StdEncoding, s)
+	case "base64url":
+		return decodeBase64(base64.URLEncoding, s)
+	case "base16":
+		return hex.DecodeString(s)
+	case "quoted-printable":
+		return io.ReadAll(quotedprintable.NewReader(strings.NewReader(s)))
+	}
+	return nil, ErrUnknownContentEncoding
+}
+
+// decodeBase64 decodes base64 with or without padding.
+func decodeBase64(enc *base64.Encoding, s string) ([]byte, error) {
+	data, err := enc.DecodeString(s)
+	if err == nil {
+		return data, nil
+	}
+	return enc.WithPadding(base64.NoPadding).DecodeString(s)
+}
+
+// IsJSONMediaType returns whether the contentMediaType is application/json or uses the +json structured syntax suffix.
+func IsJSONMediaType(mediaType string) bool {
+	typ, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	return typ == "application/json" || strings.HasSuffix(typ, "+json")
+}
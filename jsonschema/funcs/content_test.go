@@ -0,0 +1,58 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package funcs
+
+import "testing"
+
+func TestDecodeContent(t *testing.T) {
+	var valid = map[[2]string]string{
+		{"base64", "aGVsbG8="}:          "hello",
+		{"base64", "aGVsbG8"}:           "hello",
+		{"base64url", "Pz8_"}:           "???",
+		{"base16", "68656C6C6F"}:        "hello",
+		{"quoted-printable", "h=65llo"}: "hello",
+		{"", "hello"}:                   "hello",
+	}
+	for in, want := range valid {
+		got, err := DecodeContent(in[0], in[1])
+		if err != nil {
+			t.Fatalf("unexpected error for %s %s: %v", in[0], in[1], err)
+		}
+		if string(got) != want {
+			t.Fatalf("got %q, but expected %q for %s %s", got, want, in[0], in[1])
+		}
+	}
+	if _, err := DecodeContent("base64", "not base64!"); err == nil {
+		t.Fatalf("expected error for invalid base64")
+	}
+	if _, err := DecodeContent("7bit", "hello"); err != ErrUnknownContentEncoding {
+		t.Fatalf("expected unknown content encoding, but got %v", err)
+	}
+}
+
+func TestIsJSONMediaType(t *testing.T) {
+	var mediaTypes = map[string]bool{
+		"application/json":                true,
+		"application/json; charset=utf-8": true,
+		"application/schema+json":         true,
+		"text/html":                       false,
+		"":                                false,
+	}
+	for mediaType, want := range mediaTypes {
+		if got := IsJSONMediaType(mediaType); got != want {
+			t.Fatalf("got %v, but expected %v for %q", got, want, mediaType)
+		}
+	}
+}
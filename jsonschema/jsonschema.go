@@ -19,7 +19,9 @@ import (
 
 	"github.com/katydid/parser-go-json/json"
 	"github.com/katydid/parser-go/parse"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/loader"
 	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
 	"github.com/katydid/validator-go-jsonschema/jsonschema/translate"
 	"github.com/katydid/validator-go/validator"
 	"github.com/katydid/validator-go/validator/ast"
@@ -31,7 +33,15 @@ import (
 type version string
 
 type options struct {
-	version schema.Version
+	version              schema.Version
+	contentAssertion     bool
+	metaSchemaValidation bool
+	strictKeywords       bool
+	uniqueItems          bool
+	failureLocation      bool
+	concurrentUse        bool
+	loader               loader.Loader
+	dialects             map[string]*schema.Dialect
 }
 
 func newOptions(opts []Option) *options {
@@ -53,6 +63,70 @@ func WithDefaultVersion(v schema.Version) Option {
 	}
 }
 
+// WithContentAssertion validates strings against contentEncoding, contentMediaType and contentSchema.
+// By default these keywords are only annotations.
+// Only JSON media types are checked and the decoded JSON is validated against the contentSchema.
+func WithContentAssertion() Option {
+	return func(o *options) {
+		o.contentAssertion = true
+	}
+}
+
+// WithMetaSchemaValidation validates the schema against the meta-schema of its version, before it is compiled.
+// The version is detected from $schema, otherwise it is the default version.
+// An invalid schema returns an error that wraps translate.ErrInvalidSchema and includes the location of the invalid keyword.
+// The meta-schemas allow unknown keywords, so a misspelled keyword, like "minimun", is ignored, unless WithStrictKeywords is also used.
+// Draft 3 schemas are not validated, since the Draft 3 meta-schema is not embedded.
+func WithMetaSchemaValidation() Option {
+	return func(o *options) {
+		o.metaSchemaValidation = true
+	}
+}
+
+// WithStrictKeywords returns an error for schemas that contain keywords that are not defined by the meta-schema of their version,
+// for example the misspelled "minimun", which is otherwise ignored.
+// The version is detected from $schema, otherwise it is the default version.
+// The error wraps translate.ErrUnknownKeyword and includes the location of the unknown keyword.
+// Draft 3 schemas are not checked.
+func WithStrictKeywords() Option {
+	return func(o *options) {
+		o.strictKeywords = true
+	}
+}
+
+// WithUniqueItems validates uniqueItems in a pass after the automaton, since uniqueItems cannot be validated by the automaton.
+// The pass canonicalises the items of each array that a schema with uniqueItems applies to, where numbers are compared by value and objects regardless of key order.
+// It is only supported where uniqueItems always applies, not inside anyOf, oneOf, not, if, then, else, contains, propertyNames, unevaluated keywords or dynamic references.
+// The pass requires the bytes of the value, so MatchParser returns an error if the schema contains uniqueItems.
+// Without this option, uniqueItems is not supported.
+func WithUniqueItems() Option {
+	return func(o *options) {
+		o.uniqueItems = true
+	}
+}
+
+// WithLoader loads the documents of references to other documents, for example "http://example.com/other.json#/$defs/a" or "file:///schemas/other.json".
+// Loaded documents can again refer to other documents, which are also loaded.
+// Without a loader, references to other documents are not supported.
+func WithLoader(l loader.Loader) Option {
+	return func(o *options) {
+		o.loader = l
+	}
+}
+
+// WithDialect registers a custom dialect for a $schema URI, which is interpreted as the version with only the vocabularies enabled.
+// If no vocabularies are given, all the vocabularies of the version are enabled.
+// Custom dialects that are not registered are resolved by loading their meta-schema and reading its $vocabulary,
+// where an unknown vocabulary that is required returns an error.
+func WithDialect(uri string, version schema.Version, vocabularies ...schema.Vocabulary) Option {
+	return func(o *options) {
+		if o.dialects == nil {
+			o.dialects = make(map[string]*schema.Dialect)
+		}
+		o.dialects[uri] = &schema.Dialect{Version: version, Vocabularies: vocabularies}
+	}
+}
+
 func MatchBytes(schemaStr []byte, jsonStr []byte, opts ...Option) (bool, error) {
 	i, err := NewInterpreter(schemaStr, opts...)
 	if err != nil {
@@ -69,6 +143,8 @@ func MatchParser(schemaStr []byte, p parse.Parser, opts ...Option) (bool, error)
 	return i.MatchParser(p)
 }
 
+// Matcher matches values against a schema.
+// A Matcher is not safe for concurrent use by multiple goroutines, unless it is created with WithConcurrentUse, see ConcurrentMatcher.
 type Matcher interface {
 	MatchBytes([]byte) (bool, error)
 	MatchParser(p parse.Parser) (bool, error)
@@ -80,15 +156,15 @@ type interpret struct {
 }
 
 func NewInterpreter(schemaStr []byte, opts ...Option) (Matcher, error) {
-	g, err := newGrammar(schemaStr, opts...)
+	g, u, err := translateGrammar(schemaStr, opts...)
 	if err != nil {
 		return nil, err
 	}
 	p := json.NewJSONSchemaParser()
-	return &interpret{
+	return newOptions(opts).newMatcher(&interpret{
 		parser: p,
 		g:      g,
-	}, nil
+	}, u)
 }
 
 func (i *interpret) MatchBytes(jsonStr []byte) (bool, error) {
@@ -102,14 +178,25 @@ func (i *interpret) MatchParser(p parse.Parser) (bool, error) {
 
 type memoize struct {
 	parser json.Parser
-	mem    *mem.Mem
+	// mem is the memoizer's cache, which is never shared, since it is not safe for concurrent use.
+	mem *mem.Mem
+	// g keeps the nested grammars of the content functions registered, since the memoizer instantiates functions lazily.
+	g *ast.Grammar
 }
 
 func NewMemoizer(schemaStr []byte, opts ...Option) (Matcher, error) {
-	g, err := newGrammar(schemaStr, opts...)
+	g, u, err := translateGrammar(schemaStr, opts...)
 	if err != nil {
 		return nil, err
 	}
+	m, err := newMemoizer(g)
+	if err != nil {
+		return nil, err
+	}
+	return newOptions(opts).newMatcher(m, u)
+}
+
+func newMemoizer(g *ast.Grammar) (Matcher, error) {
 	m, err := mem.New(g, mem.WithRecordSimplificationRules(), mem.WithFieldNameTable())
 	if err != nil {
 		return nil, err
@@ -118,6 +205,7 @@ func NewMemoizer(schemaStr []byte, opts ...Option) (Matcher, error) {
 	return &memoize{
 		parser: p,
 		mem:    m,
+		g:      g,
 	}, nil
 }
 
@@ -133,25 +221,43 @@ func (m *memoize) MatchParser(p parse.Parser) (bool, error) {
 type compiled struct {
 	parser json.Parser
 	auto   *auto.Auto
+	// g keeps the nested grammars of the content functions registered, since the automaton instantiates functions lazily.
+	g *ast.Grammar
 }
 
 func Compile(schemaStr []byte, opts ...Option) (Matcher, error) {
+	return compile(newOptions(opts), func() (*ast.Grammar, *translate.UniqueItems, error) {
+		return translateGrammar(schemaStr, opts...)
+	})
+}
+
+// compile compiles the grammar to an automaton or falls back to a memoizer if the automaton is too big.
+func compile(o *options, newGrammar func() (*ast.Grammar, *translate.UniqueItems, error)) (Matcher, error) {
 	p := json.NewJSONSchemaParser()
-	g, err := newGrammar(schemaStr, opts...)
+	g, u, err := newGrammar()
 	if err != nil {
 		return nil, err
 	}
 	a, err := auto.Compile(g, auto.WithRecordSimplificationRules(), auto.WithMaxBitSetSize(20), auto.WithFieldNameTable())
 	if err != nil {
 		if errors.Is(err, auto.ErrTooBig) {
-			return NewMemoizer(schemaStr)
+			g, u, err := newGrammar()
+			if err != nil {
+				return nil, err
+			}
+			m, err := newMemoizer(g)
+			if err != nil {
+				return nil, err
+			}
+			return o.newMatcher(m, u)
 		}
 		return nil, err
 	}
-	return &compiled{
+	return o.newMatcher(&compiled{
 		parser: p,
 		auto:   a,
-	}, nil
+		g:      g,
+	}, u)
 }
 
 func (c *compiled) MatchBytes(jsonStr []byte) (bool, error) {
@@ -164,6 +270,105 @@ func (c *compiled) MatchParser(p parse.Parser) (bool, error) {
 }
 
 func newGrammar(schemaStr []byte, opts ...Option) (*ast.Grammar, error) {
+	g, _, err := translateGrammar(schemaStr, opts...)
+	return g, err
+}
+
+// translateGrammar returns the grammar and, if WithUniqueItems is set, the uniqueItems that are validated after the grammar.
+func translateGrammar(schemaStr []byte, opts ...Option) (*ast.Grammar, *translate.UniqueItems, error) {
 	options := newOptions(opts)
-	return translate.NewGrammar(schemaStr, options.version)
+	u := options.newUniqueItems()
+	g, err := translate.NewGrammar(schemaStr, options.version, options.translateOptions(u)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g, u, nil
+}
+
+// newUniqueItems returns a new UniqueItems if WithUniqueItems is set, otherwise it returns nil.
+func (o *options) newUniqueItems() *translate.UniqueItems {
+	if !o.uniqueItems {
+		return nil
+	}
+	return translate.NewUniqueItems()
+}
+
+func (o *options) translateOptions(u *translate.UniqueItems) []translate.Option {
+	translateOpts := []translate.Option{}
+	if u != nil {
+		translateOpts = append(translateOpts, translate.WithUniqueItems(u))
+	}
+	if o.contentAssertion {
+		translateOpts = append(translateOpts, translate.WithContentAssertion())
+	}
+	if o.metaSchemaValidation {
+		translateOpts = append(translateOpts, translate.WithMetaSchemaValidation())
+	}
+	if o.strictKeywords {
+		translateOpts = append(translateOpts, translate.WithStrictKeywords())
+	}
+	if o.loader != nil {
+		translateOpts = append(translateOpts, translate.WithLoader(o.loader))
+	}
+	for _, uri := range std.SortedKeys(o.dialects) {
+		translateOpts = append(translateOpts, translate.WithDialect(uri, o.dialects[uri]))
+	}
+	return translateOpts
+}
+
+// newMatcher wraps the matcher to report the failure location, to validate uniqueItems and for concurrent use, if the options require it.
+func (o *options) newMatcher(m Matcher, u *translate.UniqueItems) (Matcher, error) {
+	if o.failureLocation {
+		m = withFailureLocation(m)
+	}
+	m = withUniqueItems(m, u, o.failureLocation)
+	if o.concurrentUse {
+		c, err := NewConcurrentMatcher(m)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+	return m, nil
+}
+
+var errUniqueItemsParser = errors.New("uniqueItems cannot be validated with MatchParser, since the parser can only be read once, use MatchBytes instead")
+
+// uniqueItemsMatcher validates uniqueItems after the matcher, since uniqueItems cannot be validated by the automaton.
+type uniqueItemsMatcher struct {
+	matcher     Matcher
+	uniqueItems *translate.UniqueItems
+	// failureLocation returns a *MatchError for the array with duplicate items, see WithFailureLocation.
+	failureLocation bool
+}
+
+// withUniqueItems wraps the matcher if the schema contains uniqueItems that need to be validated after the matcher.
+func withUniqueItems(m Matcher, u *translate.UniqueItems, failureLocation bool) Matcher {
+	if u == nil || !u.HasUniqueItems() {
+		return m
+	}
+	return &uniqueItemsMatcher{
+		matcher:         m,
+		uniqueItems:     u,
+		failureLocation: failureLocation,
+	}
+}
+
+func (m *uniqueItemsMatcher) MatchBytes(jsonStr []byte) (bool, error) {
+	ok, err := m.matcher.MatchBytes(jsonStr)
+	if err != nil || !ok {
+		return ok, err
+	}
+	if !m.failureLocation {
+		return m.uniqueItems.Validate(jsonStr)
+	}
+	ok, path, err := m.uniqueItems.Locate(jsonStr)
+	if err != nil || ok {
+		return ok, err
+	}
+	return false, uniqueItemsError(path, jsonStr)
+}
+
+func (m *uniqueItemsMatcher) MatchParser(p parse.Parser) (bool, error) {
+	return false, errUniqueItemsParser
 }
@@ -0,0 +1,82 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loader contains loaders that load the schema documents of references to other documents.
+package loader
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Loader loads the schema document that a URI refers to.
+// The URI does not contain a fragment.
+type Loader interface {
+	Load(uri string) ([]byte, error)
+}
+
+// Map is an in-memory loader that maps URIs to schema documents.
+type Map map[string][]byte
+
+// Load returns the schema document for the URI or an error that wraps fs.ErrNotExist.
+func (m Map) Load(uri string) ([]byte, error) {
+	data, ok := m[trimFragment(uri)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", fs.ErrNotExist, uri)
+	}
+	return data, nil
+}
+
+type fsLoader struct {
+	baseURI string
+	fsys    fs.FS
+}
+
+// NewFS returns a loader that loads the URIs that start with the baseURI from the file system,
+// where the rest of the URI is the path in the file system.
+// For example with the baseURI "http://localhost:1234/" the URI "http://localhost:1234/tree.json" is loaded from "tree.json".
+// The baseURI is a directory, so a "/" is appended if it does not end in one,
+// which makes sure that the baseURI "http://localhost:1234/dir" does not load "http://localhost:1234/dir2/tree.json".
+func NewFS(baseURI string, fsys fs.FS) Loader {
+	if !strings.HasSuffix(baseURI, "/") {
+		baseURI += "/"
+	}
+	return &fsLoader{
+		baseURI: baseURI,
+		fsys:    fsys,
+	}
+}
+
+// NewDir returns a loader that loads the URIs that start with the baseURI from a directory.
+func NewDir(baseURI string, dir string) Loader {
+	return NewFS(baseURI, os.DirFS(dir))
+}
+
+func (l *fsLoader) Load(uri string) ([]byte, error) {
+	uri = trimFragment(uri)
+	if !strings.HasPrefix(uri, l.baseURI) {
+		return nil, fmt.Errorf("%w: %s is not in %s", fs.ErrNotExist, uri, l.baseURI)
+	}
+	name := strings.TrimPrefix(uri, l.baseURI)
+	return fs.ReadFile(l.fsys, name)
+}
+
+func trimFragment(uri string) string {
+	if i := strings.Index(uri, "#"); i >= 0 {
+		return uri[:i]
+	}
+	return uri
+}
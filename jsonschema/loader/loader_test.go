@@ -0,0 +1,80 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMap(t *testing.T) {
+	l := Map{"http://example.com/a.json": []byte(`{"type": "string"}`)}
+	data, err := l.Load("http://example.com/a.json#/definitions/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"type": "string"}` {
+		t.Fatalf("unexpected document %s", data)
+	}
+	if _, err := l.Load("http://example.com/b.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected not exist error, but got %v", err)
+	}
+}
+
+func TestFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"folder/integer.json": &fstest.MapFile{Data: []byte(`{"type": "integer"}`)},
+	}
+	l := NewFS("http://localhost:1234/", fsys)
+	data, err := l.Load("http://localhost:1234/folder/integer.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"type": "integer"}` {
+		t.Fatalf("unexpected document %s", data)
+	}
+	if _, err := l.Load("http://localhost:1234/folder/string.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected not exist error, but got %v", err)
+	}
+	if _, err := l.Load("http://example.com/folder/integer.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected not exist error, but got %v", err)
+	}
+}
+
+func TestFSBaseURIBoundary(t *testing.T) {
+	fsys := fstest.MapFS{
+		"string.json":    &fstest.MapFile{Data: []byte(`{"type": "string"}`)},
+		"integer.json":   &fstest.MapFile{Data: []byte(`{"type": "integer"}`)},
+		"2/integer.json": &fstest.MapFile{Data: []byte(`{"type": "integer"}`)},
+	}
+	l := NewFS("http://localhost:1234/folder", fsys)
+	data, err := l.Load("http://localhost:1234/folder/string.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"type": "string"}` {
+		t.Fatalf("unexpected document %s", data)
+	}
+	for _, uri := range []string{
+		"http://localhost:1234/folder2/integer.json",
+		"http://localhost:1234/folderinteger.json",
+	} {
+		if _, err := l.Load(uri); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected %s to not exist, but got %v", uri, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/loader"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+func TestLoaderMap(t *testing.T) {
+	schemaStr := `
+    {
+      "$id": "http://example.com/root.json",
+      "properties": {
+        "a": {"$ref": "http://example.com/defs.json#/$defs/positive"},
+        "b": {"$ref": "tree.json"}
+      }
+    }`
+	l := loader.Map{
+		"http://example.com/defs.json": []byte(`{"$defs": {"positive": {"type": "integer", "minimum": 1}}}`),
+		"http://example.com/tree.json": []byte(`{"type": ["string", "array"], "items": {"$ref": "#"}}`),
+	}
+	tests := map[string]bool{
+		`{}`:                         true,
+		`{"a": 1}`:                   true,
+		`{"a": 0}`:                   false,
+		`{"a": "1"}`:                 false,
+		`{"b": ["a", ["b", ["c"]]]}`: true,
+		`{"b": ["a", ["b", [1]]]}`:   false,
+		`{"a": 2, "b": "leaf"}`:      true,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test), WithLoader(l))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestLoaderFS(t *testing.T) {
+	schemaStr := `
+    {
+      "$id": "file:///schemas/person.json",
+      "type": "object",
+      "properties": {
+        "name": {"$ref": "name.json"}
+      }
+    }`
+	fsys := fstest.MapFS{
+		"name.json":   &fstest.MapFile{Data: []byte(`{"$ref": "string.json", "minLength": 1}`)},
+		"string.json": &fstest.MapFile{Data: []byte(`{"type": "string"}`)},
+	}
+	l := loader.NewFS("file:///schemas/", fsys)
+	tests := map[string]bool{
+		`{"name": "ada"}`: true,
+		`{"name": ""}`:    false,
+		`{"name": 1}`:     false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test), WithLoader(l), WithDefaultVersion(schema.VersionDraft2020))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestLoaderNotFound(t *testing.T) {
+	schemaStr := `{"$ref": "http://example.com/missing.json"}`
+	if _, err := MatchBytes([]byte(schemaStr), []byte(`1`), WithLoader(loader.Map{})); err == nil {
+		t.Fatalf("expected an error for a document that cannot be loaded")
+	}
+}
+
+func TestLoaderBaseURIChange(t *testing.T) {
+	schemaStr := `
+    {
+      "id": "http://localhost:1234/scope_change_defs1.json",
+      "type": "object",
+      "properties": {"list": {"$ref": "#/definitions/baz"}},
+      "definitions": {
+        "baz": {
+          "id": "baseUriChangeFolder/",
+          "type": "array",
+          "items": {"$ref": "folderInteger.json"}
+        }
+      }
+    }`
+	l := loader.Map{
+		"http://localhost:1234/baseUriChangeFolder/folderInteger.json": []byte(`{"type": "integer"}`),
+	}
+	tests := map[string]bool{
+		`{"list": [1]}`:   true,
+		`{"list": ["a"]}`: false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test), WithLoader(l), WithDefaultVersion(schema.VersionDraft4))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"bytes"
+	encjson "encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/katydid/parser-go-json/json"
+	"github.com/katydid/parser-go/cast"
+	"github.com/katydid/parser-go/parse"
+)
+
+// WithFailureLocation makes MatchBytes and MatchParser return a *MatchError if the value does not match, which says where the value went wrong.
+// The matchers stop reading the value as soon as it can no longer match, so the location is the deepest value that was read,
+// which is where the derivative first became empty.
+// The path is kept while matching, without extra allocations if the value matches, and the byte offset is only found if the value does not match.
+// With WithUniqueItems, a value that only has duplicate items returns a *MatchError for the first array with duplicate items.
+func WithFailureLocation() Option {
+	return func(o *options) {
+		o.failureLocation = true
+	}
+}
+
+// MatchError says where a value does not match, see WithFailureLocation.
+type MatchError struct {
+	// Pointer is the json pointer of the deepest value that was read before the value could no longer match,
+	// or of the array with duplicate items if the value only does not match uniqueItems, see WithUniqueItems.
+	Pointer string
+	// Offset is the byte offset of that value in the input, or -1 if the input is not known, since MatchParser was used.
+	Offset int64
+	// Field is the name of the last field on the path, or empty if there is no field on the path.
+	Field string
+}
+
+func (e *MatchError) Error() string {
+	return fmt.Sprintf("value does not match the schema at %q (offset %d)", e.Pointer, e.Offset)
+}
+
+// locatingMatcher returns a *MatchError if the value does not match.
+type locatingMatcher struct {
+	matcher Matcher
+	parser  json.Parser
+	path    pathParser
+}
+
+func withFailureLocation(m Matcher) Matcher {
+	return &locatingMatcher{
+		matcher: m,
+		parser:  json.NewJSONSchemaParser(),
+	}
+}
+
+func (m *locatingMatcher) MatchBytes(jsonStr []byte) (bool, error) {
+	m.parser.Init(jsonStr)
+	valid, err := m.match(m.parser)
+	if err != nil || valid {
+		return valid, err
+	}
+	return false, m.path.matchError(jsonStr)
+}
+
+func (m *locatingMatcher) MatchParser(p parse.Parser) (bool, error) {
+	valid, err := m.match(p)
+	if err != nil || valid {
+		return valid, err
+	}
+	return false, m.path.matchError(nil)
+}
+
+func (m *locatingMatcher) match(p parse.Parser) (bool, error) {
+	m.path.reset(p)
+	return m.matcher.MatchParser(&m.path)
+}
+
+// pathParser keeps the path to the current token of the parser that it wraps.
+// The frames and their keys are reused, so after the first few values no more allocations are needed.
+type pathParser struct {
+	parser parse.Parser
+	hint   parse.Hint
+	// frames has a frame for each level that was entered, where the first frame is the top level.
+	frames []pathFrame
+}
+
+// pathFrame is the last field that was read at a level, where the kind is unknown if no field was read yet.
+type pathFrame struct {
+	kind parse.Kind
+	key  []byte
+}
+
+func (p *pathParser) reset(parser parse.Parser) {
+	p.parser = parser
+	p.hint = parse.UnknownHint
+	p.frames = p.frames[:0]
+	p.push()
+}
+
+func (p *pathParser) push() {
+	if len(p.frames) == cap(p.frames) {
+		p.frames = append(p.frames, pathFrame{})
+		return
+	}
+	p.frames = p.frames[:len(p.frames)+1]
+	top := &p.frames[len(p.frames)-1]
+	top.kind = parse.UnknownKind
+	top.key = top.key[:0]
+}
+
+func (p *pathParser) pop() {
+	if len(p.frames) > 1 {
+		p.frames = p.frames[:len(p.frames)-1]
+	}
+}
+
+func (p *pathParser) Next() (parse.Hint, error) {
+	hint, err := p.parser.Next()
+	if err != nil {
+		return hint, err
+	}
+	p.hint = hint
+	switch hint {
+	case parse.EnterHint:
+		p.push()
+	case parse.LeaveHint:
+		p.pop()
+	case parse.FieldHint:
+		kind, key, err := p.parser.Token()
+		if err != nil {
+			return hint, err
+		}
+		top := &p.frames[len(p.frames)-1]
+		top.kind = kind
+		top.key = append(top.key[:0], key...)
+	}
+	return hint, nil
+}
+
+func (p *pathParser) Skip() error {
+	err := p.parser.Skip()
+	switch p.hint {
+	case parse.EnterHint, parse.ValueHint:
+		// the rest of the level is skipped, including where it is left.
+		p.pop()
+	}
+	p.hint = parse.UnknownHint
+	return err
+}
+
+func (p *pathParser) Token() (parse.Kind, []byte, error) {
+	return p.parser.Token()
+}
+
+// pathToken is a property name or an array index.
+type pathToken struct {
+	name    string
+	index   int64
+	isIndex bool
+}
+
+// matchError returns the error for the current path, where the offset is found in the input if it is known.
+func (p *pathParser) matchError(input []byte) *MatchError {
+	var tokens []pathToken
+	for _, f := range p.frames {
+		switch f.kind {
+		case parse.StringKind:
+			tokens = append(tokens, pathToken{name: string(f.key)})
+		case parse.Int64Kind:
+			tokens = append(tokens, pathToken{index: cast.ToInt64(f.key), isIndex: true})
+		}
+	}
+	return newMatchError(tokens, input)
+}
+
+// newMatchError returns the MatchError of the value at the path, where the input is nil if it is not known.
+func newMatchError(tokens []pathToken, input []byte) *MatchError {
+	e := &MatchError{Offset: -1}
+	var pointer strings.Builder
+	for _, token := range tokens {
+		pointer.WriteString("/")
+		if token.isIndex {
+			pointer.WriteString(strconv.FormatInt(token.index, 10))
+		} else {
+			pointer.WriteString(pointerTokenEscaper.Replace(token.name))
+			e.Field = token.name
+		}
+	}
+	e.Pointer = pointer.String()
+	if input != nil {
+		e.Offset = offsetOf(input, tokens)
+	}
+	return e
+}
+
+// uniqueItemsError returns the MatchError of the array with duplicate items at the path, which is returned by UniqueItems.Locate.
+func uniqueItemsError(path []any, input []byte) *MatchError {
+	tokens := make([]pathToken, len(path))
+	for i, elem := range path {
+		switch e := elem.(type) {
+		case int:
+			tokens[i] = pathToken{index: int64(e), isIndex: true}
+		case string:
+			tokens[i] = pathToken{name: e}
+		}
+	}
+	return newMatchError(tokens, input)
+}
+
+var pointerTokenEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// offsetOf returns the byte offset of the value at the path in the JSON document, or -1 if the path is not found.
+func offsetOf(input []byte, path []pathToken) int64 {
+	dec := encjson.NewDecoder(bytes.NewReader(input))
+	offset := valueStart(input, 0)
+	for _, token := range path {
+		delim, err := dec.Token()
+		if err != nil {
+			return -1
+		}
+		found := false
+		for i := int64(0); !found && dec.More(); i++ {
+			switch delim {
+			case encjson.Delim('{'):
+				key, err := dec.Token()
+				if err != nil {
+					return -1
+				}
+				found = !token.isIndex && key == token.name
+			case encjson.Delim('['):
+				found = token.isIndex && i == token.index
+			default:
+				return -1
+			}
+			if found {
+				offset = valueStart(input, dec.InputOffset())
+				break
+			}
+			var skip encjson.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return -1
+			}
+		}
+		if !found {
+			return -1
+		}
+	}
+	return offset
+}
+
+// valueStart returns the offset of the start of the next value, after the whitespace and separators.
+func valueStart(input []byte, offset int64) int64 {
+	for offset < int64(len(input)) {
+		switch input[offset] {
+		case ' ', '\t', '\n', '\r', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json"
+)
+
+func TestFailureLocation(t *testing.T) {
+	schema := `
+    {
+      "properties": {
+        "a": { "properties": { "b/c": { "type": "string" } } },
+        "list": { "items": { "type": "integer" } }
+      }
+    }`
+	tests := []struct {
+		value   string
+		pointer string
+		field   string
+		at      string
+	}{
+		{`{"a": {"b/c": 1}}`, "/a/b~1c", "b/c", `1}}`},
+		{`{"list": [1, 2, "x", 4]}`, "/list/2", "list", `"x", 4]}`},
+		{`{"a": {"b/c": "ok"}, "list": [[1]]}`, "/list/0", "list", `[1]]}`},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			for name, newMatcher := range map[string]func([]byte, ...Option) (Matcher, error){
+				"interpreter": NewInterpreter,
+				"memoizer":    NewMemoizer,
+				"compiled":    Compile,
+			} {
+				m, err := newMatcher([]byte(schema), WithFailureLocation())
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := m.MatchBytes([]byte(test.value))
+				if got {
+					t.Fatalf("%s: expected no match", name)
+				}
+				var matchErr *MatchError
+				if !errors.As(err, &matchErr) {
+					t.Fatalf("%s: expected a MatchError, but got %v", name, err)
+				}
+				if matchErr.Pointer != test.pointer {
+					t.Errorf("%s: want pointer %q, but got %q", name, test.pointer, matchErr.Pointer)
+				}
+				if matchErr.Field != test.field {
+					t.Errorf("%s: want field %q, but got %q", name, test.field, matchErr.Field)
+				}
+				if want := int64(strings.Index(test.value, test.at)); matchErr.Offset != want {
+					t.Errorf("%s: want offset %d, but got %d", name, want, matchErr.Offset)
+				}
+			}
+		})
+	}
+}
+
+func TestFailureLocationUniqueItems(t *testing.T) {
+	schema := `{"properties": {"a": {"items": {"uniqueItems": true}}}}`
+	value := `{"a": [[1, 2], [3, 3]]}`
+	m, err := Compile([]byte(schema), WithFailureLocation(), WithUniqueItems())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := m.MatchBytes([]byte(value))
+	if got {
+		t.Fatal("expected no match")
+	}
+	var matchErr *MatchError
+	if !errors.As(err, &matchErr) {
+		t.Fatalf("expected a MatchError, but got %v", err)
+	}
+	if matchErr.Pointer != "/a/1" || matchErr.Field != "a" {
+		t.Errorf("want pointer /a/1 and field a, but got %q and %q", matchErr.Pointer, matchErr.Field)
+	}
+	if want := int64(strings.Index(value, "[3, 3]")); matchErr.Offset != want {
+		t.Errorf("want offset %d, but got %d", want, matchErr.Offset)
+	}
+}
+
+func TestFailureLocationMatch(t *testing.T) {
+	m, err := Compile([]byte(`{"items": {"type": "integer"}}`), WithFailureLocation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []string{`[1, 2]`, `[1, "a"]`, `[3]`} {
+		got, err := m.MatchBytes([]byte(value))
+		if want := value != `[1, "a"]`; got != want {
+			t.Fatalf("%s: want %v, but got %v", value, want, got)
+		}
+		if got && err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFailureLocationMatchParser(t *testing.T) {
+	m, err := NewInterpreter([]byte(`{"properties": {"a": {"type": "string"}}}`), WithFailureLocation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := json.NewJSONSchemaParser()
+	p.Init([]byte(`{"a": 1}`))
+	_, err = m.MatchParser(p)
+	var matchErr *MatchError
+	if !errors.As(err, &matchErr) {
+		t.Fatalf("expected a MatchError, but got %v", err)
+	}
+	if matchErr.Pointer != "/a" || matchErr.Offset != -1 {
+		t.Fatalf("want pointer /a without an offset, but got %#v", matchErr)
+	}
+}
+
+func TestFailureLocationAllocs(t *testing.T) {
+	schema := []byte(`{"properties": {"a": {"items": {"type": "integer"}}, "b": {"type": "string"}}}`)
+	value := []byte(`{"a": [1, 2, 3], "b": "c", "d": {"e": [true]}}`)
+	allocs := func(opts ...Option) float64 {
+		m, err := Compile(schema, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return testing.AllocsPerRun(100, func() {
+			if ok, err := m.MatchBytes(value); !ok || err != nil {
+				t.Fatalf("expected match, but got %v, %v", ok, err)
+			}
+		})
+	}
+	without := allocs()
+	with := allocs(WithFailureLocation())
+	if with != without {
+		t.Fatalf("want %v allocations with the failure location, but got %v", without, with)
+	}
+}
+
+func TestOffsetOf(t *testing.T) {
+	input := []byte(` {"a" : [ 1 ,{"b":  null}], "c~d": "e"}`)
+	tests := []struct {
+		path []pathToken
+		at   string
+	}{
+		{nil, `{"a"`},
+		{[]pathToken{{name: "a"}}, `[ 1`},
+		{[]pathToken{{name: "a"}, {index: 1, isIndex: true}}, `{"b"`},
+		{[]pathToken{{name: "a"}, {index: 1, isIndex: true}, {name: "b"}}, `null`},
+		{[]pathToken{{name: "c~d"}}, `"e"`},
+	}
+	for _, test := range tests {
+		if got, want := offsetOf(input, test.path), int64(strings.Index(string(input), test.at)); got != want {
+			t.Errorf("%v: want %d, but got %d", test.path, want, got)
+		}
+	}
+	if got := offsetOf(input, []pathToken{{name: "missing"}}); got != -1 {
+		t.Errorf("want -1 for a missing path, but got %d", got)
+	}
+}
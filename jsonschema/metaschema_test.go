@@ -0,0 +1,162 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/translate"
+)
+
+func TestRefMetaSchema(t *testing.T) {
+	metaSchemas := []string{
+		"http://json-schema.org/draft-04/schema#",
+		"http://json-schema.org/draft-06/schema#",
+		"http://json-schema.org/draft-07/schema#",
+		"https://json-schema.org/draft/2019-09/schema",
+		"https://json-schema.org/draft/2020-12/schema",
+	}
+	tests := map[string]bool{
+		`{"schema": {}}`: true,
+		`{"schema": {"type": "string", "maxLength": 3}}`:            true,
+		`{"schema": {"properties": {"a": {"type": "integer"}}}}`:    true,
+		`{"schema": {"type": 1}}`:                                   false,
+		`{"schema": {"maxLength": -1}}`:                             false,
+		`{"schema": {"properties": {"a": {"minLength": "three"}}}}`: false,
+		`{"schema": "string"}`:                                      false,
+	}
+	for _, metaSchema := range metaSchemas {
+		schema := `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"properties": {
+				"schema": {"$ref": "` + metaSchema + `"}
+			}
+		}`
+		for test, want := range tests {
+			t.Run(metaSchema+test, func(t *testing.T) {
+				got, err := MatchBytes([]byte(schema), []byte(test))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Error()
+				}
+			})
+		}
+	}
+}
+
+func TestMetaSchemaValidation(t *testing.T) {
+	tests := []struct {
+		schema   string
+		location string
+	}{
+		{`{"type": "string", "maxLength": 3}`, ""},
+		{`{"$schema": "http://json-schema.org/draft-04/schema#", "properties": {"a": {"type": "integer", "minimum": 1}}}`, ""},
+		{`{"type": "strng"}`, "#/type"},
+		{`{"properties": {"a": {"minLength": -1}}}`, "#/properties/a/minLength"},
+		{`{"$defs": {"a/b": {"allOf": [{}, {"required": "a"}]}}}`, "#/$defs/a~1b/allOf/1/required"},
+		{`{"$schema": "http://json-schema.org/draft-04/schema#", "properties": {"a": {"exclusiveMinimum": true}}}`, "#/properties/a/exclusiveMinimum"},
+		{`{"$schema": "http://json-schema.org/draft-07/schema#", "items": [{"type": "string"}, {"pattern": 1}]}`, "#/items/1/pattern"},
+		{`{"$schema": "https://json-schema.org/draft/2019-09/schema", "dependentSchemas": {"a": {"maxItems": "1"}}}`, "#/dependentSchemas/a/maxItems"},
+	}
+	for _, test := range tests {
+		t.Run(test.schema, func(t *testing.T) {
+			_, err := Compile([]byte(test.schema), WithMetaSchemaValidation())
+			if len(test.location) == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, translate.ErrInvalidSchema) {
+				t.Fatalf("want invalid schema error, but got %v", err)
+			}
+			if !strings.HasSuffix(err.Error(), " at "+test.location) {
+				t.Fatalf("want location %s, but got %v", test.location, err)
+			}
+		})
+	}
+}
+
+func TestCompilerMetaSchemaValidation(t *testing.T) {
+	c := NewCompiler(WithMetaSchemaValidation())
+	if err := c.AddResource("http://example.com/a.json", []byte(`{"type": "string"}`)); err != nil {
+		t.Fatal(err)
+	}
+	err := c.AddResource("http://example.com/b.json", []byte(`{"properties": {"a": {"type": "strng"}}}`))
+	if !errors.Is(err, translate.ErrInvalidSchema) {
+		t.Fatalf("want invalid schema error, but got %v", err)
+	}
+}
+
+func TestMetaSchemaValidationUnknownKeyword(t *testing.T) {
+	m, err := Compile([]byte(`{"type": "integer", "minimun": 1}`), WithMetaSchemaValidation())
+	if err != nil {
+		t.Fatalf("unknown keywords are allowed by the meta-schema, but got %v", err)
+	}
+	got, err := m.MatchBytes([]byte(`0`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got {
+		t.Fatalf("want the unknown keyword to be ignored")
+	}
+}
+
+func TestStrictKeywords(t *testing.T) {
+	tests := []struct {
+		schema   string
+		location string
+	}{
+		{`{"type": "integer", "minimum": 1}`, ""},
+		{`{"properties": {"minimun": {"type": "integer"}}, "enum": [{"minimun": 1}]}`, ""},
+		{`{"$schema": "http://json-schema.org/draft-04/schema#", "$ref": "#/definitions/a", "definitions": {"a": {}}}`, ""},
+		{`{"type": "integer", "minimun": 1}`, "#/minimun"},
+		{`{"properties": {"a/b": {"allOf": [{}, {"minimun": 1}]}}}`, "#/properties/a~1b/allOf/1/minimun"},
+		{`{"$schema": "http://json-schema.org/draft-04/schema#", "$defs": {}}`, "#/$defs"},
+		{`{"$schema": "http://json-schema.org/draft-07/schema#", "items": [{}, {"prefixItems": []}]}`, "#/items/1/prefixItems"},
+	}
+	for _, test := range tests {
+		t.Run(test.schema, func(t *testing.T) {
+			_, err := Compile([]byte(test.schema), WithStrictKeywords())
+			if len(test.location) == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, translate.ErrUnknownKeyword) {
+				t.Fatalf("want unknown keyword error, but got %v", err)
+			}
+			if !strings.HasSuffix(err.Error(), " at "+test.location) {
+				t.Fatalf("want location %s, but got %v", test.location, err)
+			}
+		})
+	}
+	c := NewCompiler(WithStrictKeywords())
+	if err := c.AddResource("http://example.com/a.json", []byte(`{"maxLength": 3, "maxlength": 3}`)); !errors.Is(err, translate.ErrUnknownKeyword) {
+		t.Fatalf("want unknown keyword error, but got %v", err)
+	}
+}
+
+func TestMetaSchemaValidationDraft3(t *testing.T) {
+	if _, err := Compile([]byte(`{"type": "string", "required": true}`), WithDefaultVersion(schema.VersionDraft3), WithMetaSchemaValidation(), WithStrictKeywords()); err != nil {
+		t.Fatalf("Draft 3 schemas are not validated, but got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+// Annotations are the annotations of the schemas that apply to a location in the value.
+// The values of each keyword are in the order that the schemas were evaluated in, so the outermost schema is first.
+type Annotations struct {
+	Titles       []string `json:"titles,omitempty"`
+	Descriptions []string `json:"descriptions,omitempty"`
+	Defaults     []any    `json:"defaults,omitempty"`
+	Examples     []any    `json:"examples,omitempty"`
+	// Deprecated is set if any of the schemas is deprecated.
+	Deprecated bool `json:"deprecated,omitempty"`
+}
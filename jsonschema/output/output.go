@@ -0,0 +1,106 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package output contains the results of validating a value against a schema,
+// in the output formats of the JSON Schema specification: flag, basic, detailed and verbose.
+package output
+
+// Unit is an output unit, which is the result of validating the value at the instance location against the keyword at the keyword location.
+type Unit struct {
+	Valid bool `json:"valid"`
+	// KeywordLocation is the json pointer of the keyword, relative to the root schema, which includes the references that were followed, for example "/properties/a/$ref/type".
+	KeywordLocation string `json:"keywordLocation"`
+	// AbsoluteKeywordLocation is the absolute URI of the keyword, after references are resolved.
+	AbsoluteKeywordLocation string `json:"absoluteKeywordLocation,omitempty"`
+	// InstanceLocation is the json pointer of the value.
+	InstanceLocation string `json:"instanceLocation"`
+	// Error explains why the value is not valid.
+	Error string `json:"error,omitempty"`
+	// Errors are the nested units that are not valid.
+	Errors []*Unit `json:"errors,omitempty"`
+	// Annotations are the nested units that are valid.
+	Annotations []*Unit `json:"annotations,omitempty"`
+}
+
+// Add adds the nested unit to Errors if it is not valid, otherwise to Annotations.
+// It does not change whether this unit is valid, since that depends on the keyword, for example not is valid if its nested unit is not valid.
+func (u *Unit) Add(child *Unit) {
+	if child.Valid {
+		u.Annotations = append(u.Annotations, child)
+	} else {
+		u.Errors = append(u.Errors, child)
+	}
+}
+
+// Flag is the flag output format, which only says whether the value is valid.
+type Flag struct {
+	Valid bool `json:"valid"`
+}
+
+// Flag returns the flag output format.
+func (u *Unit) Flag() *Flag {
+	return &Flag{Valid: u.Valid}
+}
+
+// Basic returns the basic output format, which is a flat list of the units that explain why the value is not valid.
+func (u *Unit) Basic() *Unit {
+	basic := u.shallow()
+	basic.Errors = u.leafErrors(nil)
+	return basic
+}
+
+// leafErrors appends the units that are not valid and that have an error, without their nested units.
+func (u *Unit) leafErrors(errs []*Unit) []*Unit {
+	for _, child := range u.Errors {
+		if len(child.Error) > 0 {
+			errs = append(errs, child.shallow())
+		}
+		errs = child.leafErrors(errs)
+	}
+	return errs
+}
+
+// Detailed returns the detailed output format, which is the hierarchy of the units that are not valid,
+// where a nested unit without its own error and with only one nested unit is replaced by its nested unit.
+func (u *Unit) Detailed() *Unit {
+	detailed := u.shallow()
+	for _, child := range u.Errors {
+		detailed.Errors = append(detailed.Errors, child.detailed())
+	}
+	return detailed
+}
+
+func (u *Unit) detailed() *Unit {
+	if len(u.Error) == 0 && len(u.Errors) == 1 {
+		return u.Errors[0].detailed()
+	}
+	return u.Detailed()
+}
+
+// Verbose returns the verbose output format, which is the hierarchy of all the units, including the valid units.
+// Only the units that were explained are included, so the result of a valid value has no annotations.
+func (u *Unit) Verbose() *Unit {
+	return u
+}
+
+// shallow returns a copy of the unit without its nested units.
+func (u *Unit) shallow() *Unit {
+	return &Unit{
+		Valid:                   u.Valid,
+		KeywordLocation:         u.KeywordLocation,
+		AbsoluteKeywordLocation: u.AbsoluteKeywordLocation,
+		InstanceLocation:        u.InstanceLocation,
+		Error:                   u.Error,
+	}
+}
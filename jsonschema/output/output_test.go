@@ -0,0 +1,91 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func newExample() *Unit {
+	return &Unit{
+		Valid: false,
+		Errors: []*Unit{
+			{
+				KeywordLocation:  "/properties",
+				InstanceLocation: "",
+				Error:            "some properties are not valid",
+				Errors: []*Unit{
+					{
+						KeywordLocation:  "/properties/a",
+						InstanceLocation: "/a",
+						Errors: []*Unit{
+							{KeywordLocation: "/properties/a/type", InstanceLocation: "/a", Error: "expected string, but got number"},
+						},
+						Annotations: []*Unit{
+							{Valid: true, KeywordLocation: "/properties/a/minimum", InstanceLocation: "/a"},
+						},
+					},
+				},
+			},
+		},
+		Annotations: []*Unit{
+			{Valid: true, KeywordLocation: "/required", InstanceLocation: ""},
+		},
+	}
+}
+
+func TestFormats(t *testing.T) {
+	u := newExample()
+	tests := []struct {
+		name   string
+		format any
+		want   string
+	}{
+		{"flag", u.Flag(), `{"valid":false}`},
+		{"basic", u.Basic(), `{"valid":false,"keywordLocation":"","instanceLocation":"","errors":[` +
+			`{"valid":false,"keywordLocation":"/properties","instanceLocation":"","error":"some properties are not valid"},` +
+			`{"valid":false,"keywordLocation":"/properties/a/type","instanceLocation":"/a","error":"expected string, but got number"}]}`},
+		{"detailed", u.Detailed(), `{"valid":false,"keywordLocation":"","instanceLocation":"","errors":[` +
+			`{"valid":false,"keywordLocation":"/properties","instanceLocation":"","error":"some properties are not valid","errors":[` +
+			`{"valid":false,"keywordLocation":"/properties/a/type","instanceLocation":"/a","error":"expected string, but got number"}]}]}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.want {
+				t.Fatalf("want %s, but got %s", test.want, data)
+			}
+		})
+	}
+	if u.Verbose() != u {
+		t.Fatal("expected verbose to be the whole hierarchy")
+	}
+}
+
+func TestAdd(t *testing.T) {
+	u := &Unit{Valid: true}
+	u.Add(&Unit{Valid: true, KeywordLocation: "/minimum"})
+	u.Add(&Unit{Valid: false, KeywordLocation: "/type"})
+	if len(u.Annotations) != 1 || len(u.Errors) != 1 {
+		t.Fatalf("expected one annotation and one error, but got %d and %d", len(u.Annotations), len(u.Errors))
+	}
+	if !u.Valid {
+		t.Fatal("expected Add to not change whether the unit is valid")
+	}
+}
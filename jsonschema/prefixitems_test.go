@@ -0,0 +1,84 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+func TestPrefixItems(t *testing.T) {
+	schema := `
+    {
+      "type": "array",
+      "prefixItems": [
+        { "type": "string" },
+        { "type": "integer" }
+      ],
+      "items": { "type": "boolean" }
+    }`
+	tests := map[string]bool{
+		`[]`:                     true,
+		`["a"]`:                  true,
+		`["a", 1]`:               true,
+		`["a", 1, true, false]`:  true,
+		`[1]`:                    false,
+		`["a", "b"]`:             false,
+		`["a", 1, "not a bool"]`: false,
+		`{"not": "an array"}`:    false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schema), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Error()
+			}
+		})
+	}
+}
+
+func TestItemsArrayBeforeDraft2020(t *testing.T) {
+	schemaStr := `
+    {
+      "type": "array",
+      "items": [
+        { "type": "string" },
+        { "type": "integer" }
+      ],
+      "additionalItems": { "type": "boolean" }
+    }`
+	tests := map[string]bool{
+		`["a", 1, true]`: true,
+		`["a", 1, 2]`:    false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test), WithDefaultVersion(schema.VersionDraft2019))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Error()
+			}
+		})
+	}
+	if _, err := MatchBytes([]byte(schemaStr), []byte(`[]`), WithDefaultVersion(schema.VersionDraft2020)); err == nil {
+		t.Fatal("expected error for an array of items in draft 2020-12")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPropertyNames(t *testing.T) {
+	schemaStr := `
+    {
+      "$defs": {
+        "identifier": {"type": "string", "pattern": "^[a-z_]+$"}
+      },
+      "propertyNames": {
+        "$ref": "#/$defs/identifier",
+        "maxLength": 5,
+        "not": {"enum": ["admin", "root"]}
+      }
+    }`
+	tests := map[string]bool{
+		`{}`:                    true,
+		`{"abc": 1, "d_e": 2}`:  true,
+		`{"abcdef": 1}`:         false,
+		`{"ABC": 1}`:            false,
+		`{"abc": 1, "root": 2}`: false,
+		`"notanobject"`:         true,
+		`[1, 2]`:                true,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestPropertyNamesConst(t *testing.T) {
+	schemaStr := `
+    {
+      "type": "object",
+      "propertyNames": {"anyOf": [{"const": "foo"}, {"minLength": 4}]}
+    }`
+	tests := map[string]bool{
+		`{"foo": 1}`:         true,
+		`{"quux": 1}`:        true,
+		`{"bar": 1}`:         false,
+		`{"foo": 1, "b": 2}`: false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestPropertyNamesLength(t *testing.T) {
+	// repeat counts above 1000 are not supported by regular expressions.
+	schemaStr := `{"propertyNames": {"minLength": 1200, "maxLength": 2500}}`
+	tests := map[int]bool{
+		1199: false,
+		1200: true,
+		2500: true,
+		2501: false,
+	}
+	for length, want := range tests {
+		value := `{"` + strings.Repeat("é", length) + `": 1}`
+		got, err := MatchBytes([]byte(schemaStr), []byte(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("%d: want %v, got %v", length, want, got)
+		}
+	}
+}
+
+func TestPropertyNamesFormat(t *testing.T) {
+	if _, err := MatchBytes([]byte(`{"propertyNames": {"format": "email"}}`), []byte(`{}`)); err == nil {
+		t.Fatalf("expected an error for a format in propertyNames")
+	}
+	if _, err := MatchBytes([]byte(`{"propertyNames": {"format": "unknown"}}`), []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+}
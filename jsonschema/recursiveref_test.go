@@ -0,0 +1,183 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+func TestRecursiveRef(t *testing.T) {
+	schemas := map[string]struct {
+		schema string
+		tests  map[string]bool
+	}{
+		"with nesting": {
+			schema: `{
+				"$id": "http://localhost:4242/recursiveRef3/schema.json",
+				"$recursiveAnchor": true,
+				"$defs": {
+					"myobject": {
+						"$id": "myobject.json",
+						"$recursiveAnchor": true,
+						"anyOf": [
+							{"type": "string"},
+							{"type": "object", "additionalProperties": {"$recursiveRef": "#"}}
+						]
+					}
+				},
+				"anyOf": [
+					{"type": "integer"},
+					{"$ref": "#/$defs/myobject"}
+				]
+			}`,
+			tests: map[string]bool{
+				`"foo"`:                    true,
+				`1`:                        true,
+				`{"foo": "hi"}`:            true,
+				`{"foo": 1}`:               true,
+				`{"foo": {"bar": 1}}`:      true,
+				`{"foo": {"bar": true}}`:   false,
+				`{"foo": {"bar": [true]}}`: false,
+			},
+		},
+		"recursiveAnchor false works like ref": {
+			schema: `{
+				"$id": "http://localhost:4242/recursiveRef4/schema.json",
+				"$recursiveAnchor": false,
+				"$defs": {
+					"myobject": {
+						"$id": "myobject.json",
+						"$recursiveAnchor": false,
+						"anyOf": [
+							{"type": "string"},
+							{"type": "object", "additionalProperties": {"$recursiveRef": "#"}}
+						]
+					}
+				},
+				"anyOf": [
+					{"type": "integer"},
+					{"$ref": "#/$defs/myobject"}
+				]
+			}`,
+			tests: map[string]bool{
+				`{"foo": "hi"}`:          true,
+				`{"foo": 1}`:             false,
+				`{"foo": {"bar": "hi"}}`: true,
+				`{"foo": {"bar": 1}}`:    false,
+			},
+		},
+		"no recursiveAnchor in the outer schema resource": {
+			schema: `{
+				"$id": "http://localhost:4242/recursiveRef6/base.json",
+				"anyOf": [
+					{"type": "boolean"},
+					{
+						"type": "object",
+						"additionalProperties": {
+							"$id": "http://localhost:4242/recursiveRef6/inner.json",
+							"$comment": "there is no $recursiveAnchor: true in the outer schema resource",
+							"$recursiveAnchor": true,
+							"anyOf": [
+								{"type": "integer"},
+								{"type": "object", "additionalProperties": {"$recursiveRef": "#"}}
+							]
+						}
+					}
+				]
+			}`,
+			tests: map[string]bool{
+				`{"foo": 1}`:              true,
+				`{"foo": {"bar": 1}}`:     true,
+				`{"foo": {"bar": true}}`:  false,
+				`{"foo": {"bar": "baz"}}`: false,
+			},
+		},
+		"dynamic destination": {
+			schema: `{
+				"$id": "main.json",
+				"$defs": {
+					"inner": {
+						"$id": "inner.json",
+						"$recursiveAnchor": true,
+						"additionalProperties": {"$recursiveRef": "#"}
+					}
+				},
+				"if": {"properties": {"kind": {"const": "any"}}, "required": ["kind"]},
+				"then": {
+					"$id": "anyLeafNode.json",
+					"$recursiveAnchor": true,
+					"$ref": "inner.json"
+				},
+				"else": {
+					"$id": "integerNode.json",
+					"$recursiveAnchor": true,
+					"type": ["object", "integer"],
+					"$ref": "inner.json"
+				}
+			}`,
+			tests: map[string]bool{
+				`{"kind": "any", "foo": {"bar": true}}`: true,
+				`{"other": 1, "foo": {"bar": 1}}`:       true,
+				`{"other": 1, "foo": {"bar": true}}`:    false,
+			},
+		},
+	}
+	for name, s := range schemas {
+		for test, want := range s.tests {
+			t.Run(name+":"+test, func(t *testing.T) {
+				got, err := MatchBytes([]byte(s.schema), []byte(test), WithDefaultVersion(schema.VersionDraft2019))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("want %v, got %v", want, got)
+				}
+			})
+		}
+	}
+}
+
+func TestRecursiveRefSchemaURI(t *testing.T) {
+	// $recursiveRef is only supported in Draft 2019-09, so it is only followed if the $schema is detected as Draft 2019-09.
+	for _, uri := range []string{
+		"https://json-schema.org/draft/2019-09/schema",
+		"http://json-schema.org/draft/2019-09/schema",
+	} {
+		schemaStr := `{"$schema": "` + uri + `", "$recursiveAnchor": true, "properties": {"child": {"$recursiveRef": "#"}}, "required": ["name"]}`
+		tests := map[string]bool{
+			`{"name": 1, "child": {"name": 2}}`: true,
+			`{"name": 1, "child": {}}`:          false,
+		}
+		for test, want := range tests {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test), WithDefaultVersion(schema.VersionDraft2020))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("%s: %s: want %v, got %v", uri, test, want, got)
+			}
+		}
+	}
+}
+
+func TestRecursiveRefUnresolved(t *testing.T) {
+	schemaStr := `{"properties": {"child": {"$recursiveRef": "#/$defs/missing"}}}`
+	_, err := MatchBytes([]byte(schemaStr), []byte(`{"child": 1}`), WithDefaultVersion(schema.VersionDraft2019))
+	if err == nil {
+		t.Fatal("expected an error for a $recursiveRef to a schema that does not exist")
+	}
+}
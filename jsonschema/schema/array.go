@@ -18,9 +18,19 @@ package schema
 type Array struct {
 	AdditionalItems *Additional `json:"additionalItems,omitempty"`
 	Items           *Items      `json:"items,omitempty"`
-	MaxItems        *uint64     `json:"maxItems,omitempty"`
-	MinItems        uint64      `json:"minItems,omitempty"`
-	UniqueItems     bool        `json:"uniqueItems,omitempty"`
+	// PrefixItems replaces the array form of Items since Draft 2020-12, where Items then applies to the rest of the items.
+	PrefixItems []*Schema `json:"prefixItems,omitempty"`
+	MaxItems    *uint64   `json:"maxItems,omitempty"`
+	MinItems    uint64    `json:"minItems,omitempty"`
+	UniqueItems bool      `json:"uniqueItems,omitempty"`
+	// Contains is supported since Draft 6 and MaxContains and MinContains since Draft 2019-09.
+	// MaxContains and MinContains are ignored if Contains is not present.
+	Contains    *Schema `json:"contains,omitempty"`
+	MaxContains *uint64 `json:"maxContains,omitempty"`
+	MinContains *uint64 `json:"minContains,omitempty"`
+	// UnevaluatedItems is supported since Draft 2019-09.
+	// It applies to all items that were not evaluated by this schema or any of its in-place applicators.
+	UnevaluatedItems *Schema `json:"unevaluatedItems,omitempty"`
 }
 
 func (this Array) GetAdditionalItems() *Additional {
@@ -38,6 +48,7 @@ func (this Array) GetItems() *Items {
 }
 
 func (this Array) HasArrayConstraints() bool {
-	return this.AdditionalItems != nil || this.Items != nil ||
-		this.MaxItems != nil || this.MinItems > 0 || this.UniqueItems
+	return this.AdditionalItems != nil || this.Items != nil || this.PrefixItems != nil ||
+		this.MaxItems != nil || this.MinItems > 0 || this.UniqueItems ||
+		this.Contains != nil
 }
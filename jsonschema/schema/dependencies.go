@@ -49,6 +49,12 @@ func (this *Dependency) UnmarshalJSON(buf []byte) error {
 		*this = Dependency{Schema: s}
 		return nil
 	}
+	var str string
+	if err := std.UnmarshalJSON(buf, &str); err == nil {
+		// Draft 3 allows a single property name.
+		*this = Dependency{RequiredProperty: []string{str}}
+		return nil
+	}
 	var ss []string
 	if err := std.UnmarshalJSON(buf, &ss); err != nil {
 		return err
@@ -0,0 +1,144 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+)
+
+// Draft3 contains the keywords that are only supported in Draft 3.
+type Draft3 struct {
+	// Extends is a schema or an array of schemas, which all need to match, like allOf since Draft 4.
+	Extends *Items `json:"extends,omitempty"`
+	// Disallow is a type or an array of types and schemas, where none of them may match.
+	Disallow *TypeUnion `json:"disallow,omitempty"`
+	// DivisibleBy was renamed to multipleOf in Draft 4.
+	DivisibleBy *float64 `json:"divisibleBy,omitempty"`
+	// RequiredProperty is "required": true, which requires the parent object to have this property.
+	// Since Draft 4 required is an array of property names on the parent object.
+	RequiredProperty bool `json:"-"`
+	// TypeUnion is set instead of Type if type contains "any" or schemas.
+	TypeUnion *TypeUnion `json:"-"`
+	// draft3Err is the error of parsing the schema in the later forms, which is set if the schema was parsed in the Draft 3 forms.
+	draft3Err error
+}
+
+func (this Draft3) HasDraft3Constraints() bool {
+	return this.Extends != nil || this.Disallow != nil || this.DivisibleBy != nil || this.TypeUnion != nil
+}
+
+// TypeUnion is a Draft 3 type or an array of types, where each type is a simple type, "any" or a schema.
+type TypeUnion struct {
+	Types []SimpleType
+	// Any is set if one of the types is "any", which matches any value.
+	Any     bool
+	Schemas []*Schema
+}
+
+func (this *TypeUnion) UnmarshalJSON(buf []byte) error {
+	var raws []json.RawMessage
+	if err := std.UnmarshalJSON(buf, &raws); err != nil {
+		raws = []json.RawMessage{buf}
+	}
+	u := TypeUnion{}
+	for _, raw := range raws {
+		var s string
+		if err := std.UnmarshalJSON(raw, &s); err == nil {
+			if s == "any" {
+				u.Any = true
+				continue
+			}
+			simpleType, err := newSimpleType(s)
+			if err != nil {
+				return err
+			}
+			u.Types = append(u.Types, simpleType)
+			continue
+		}
+		var sch *Schema
+		if err := std.UnmarshalJSON(raw, &sch); err != nil {
+			return fmt.Errorf("type must be a string or a schema: %w", err)
+		}
+		u.Schemas = append(u.Schemas, sch)
+	}
+	*this = u
+	return nil
+}
+
+func (this *TypeUnion) MarshalJSON() ([]byte, error) {
+	types := []any{}
+	if this.Any {
+		types = append(types, "any")
+	}
+	for _, t := range this.Types {
+		types = append(types, t)
+	}
+	for _, s := range this.Schemas {
+		types = append(types, s)
+	}
+	return json.Marshal(types)
+}
+
+// unmarshalDraft3 parses a schema that uses the Draft 3 forms of required and type, which cannot be parsed as the later forms.
+// If the schema also cannot be parsed as Draft 3, then the original error is returned.
+// The version is only known after parsing, so the original error is kept, which CheckDraft3Forms returns if the schema is not a Draft 3 schema.
+func (this *Schema) unmarshalDraft3(buf []byte, original error) error {
+	var fields map[string]json.RawMessage
+	if err := std.UnmarshalJSON(buf, &fields); err != nil {
+		return original
+	}
+	var required bool
+	if raw, ok := fields["required"]; ok && std.UnmarshalJSON(raw, &required) == nil {
+		delete(fields, "required")
+	}
+	var typeUnion *TypeUnion
+	if raw, ok := fields["type"]; ok {
+		var t Type
+		if err := std.UnmarshalJSON(raw, &t); err != nil {
+			typeUnion = &TypeUnion{}
+			if err := std.UnmarshalJSON(raw, typeUnion); err != nil {
+				return original
+			}
+			delete(fields, "type")
+		}
+	}
+	rest, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	*this = Schema{}
+	if err := this.unmarshalFields(rest); err != nil {
+		return err
+	}
+	this.RequiredProperty = required
+	this.TypeUnion = typeUnion
+	this.draft3Err = original
+	return nil
+}
+
+// CheckDraft3Forms returns the original parse error of the first subschema that was parsed in the Draft 3 forms of required and type,
+// but whose detected or default version is not Draft 3.
+func (this *Schema) CheckDraft3Forms() error {
+	var err error
+	this.Walk(func(s *Schema) {
+		if err == nil && s.draft3Err != nil && s.GetVersion() != VersionDraft3 {
+			err = s.draft3Err
+		}
+	})
+	return err
+}
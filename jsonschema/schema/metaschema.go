@@ -0,0 +1,130 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+)
+
+// metaSchema is an official meta-schema, which is embedded so that references to it can be resolved without loading it.
+type metaSchema struct {
+	version Version
+	schema  string
+}
+
+// metaSchemas maps the canonical URIs of the official meta-schemas, including the vocabulary meta-schemas, to their versions and contents.
+var metaSchemas = map[string]metaSchema{
+	"http://json-schema.org/draft-04/schema": {VersionDraft4, SchemaDraft4},
+	"http://json-schema.org/draft-06/schema": {VersionDraft6, SchemaDraft6},
+	"http://json-schema.org/draft-07/schema": {VersionDraft7, SchemaDraft7},
+
+	"https://json-schema.org/draft/2019-09/schema":          {VersionDraft2019, SchemaDraft2019},
+	"https://json-schema.org/draft/2019-09/meta/core":       {VersionDraft2019, SchemaDraft2019Core},
+	"https://json-schema.org/draft/2019-09/meta/applicator": {VersionDraft2019, SchemaDraft2019Applicator},
+	"https://json-schema.org/draft/2019-09/meta/validation": {VersionDraft2019, SchemaDraft2019Validation},
+	"https://json-schema.org/draft/2019-09/meta/meta-data":  {VersionDraft2019, SchemaDraft2019MetaData},
+	"https://json-schema.org/draft/2019-09/meta/format":     {VersionDraft2019, SchemaDraft2019Format},
+	"https://json-schema.org/draft/2019-09/meta/content":    {VersionDraft2019, SchemaDraft2019Content},
+
+	"https://json-schema.org/draft/2020-12/schema":                 {VersionDraft2020, SchemaDraft2020},
+	"https://json-schema.org/draft/2020-12/meta/core":              {VersionDraft2020, SchemaDraft2020Core},
+	"https://json-schema.org/draft/2020-12/meta/applicator":        {VersionDraft2020, SchemaDraft2020Applicator},
+	"https://json-schema.org/draft/2020-12/meta/unevaluated":       {VersionDraft2020, SchemaDraft2020Unevaluated},
+	"https://json-schema.org/draft/2020-12/meta/validation":        {VersionDraft2020, SchemaDraft2020Validation},
+	"https://json-schema.org/draft/2020-12/meta/meta-data":         {VersionDraft2020, SchemaDraft2020MetaData},
+	"https://json-schema.org/draft/2020-12/meta/format-annotation": {VersionDraft2020, SchemaDraft2020FormatAnnotation},
+	"https://json-schema.org/draft/2020-12/meta/format-assertion":  {VersionDraft2020, SchemaDraft2020FormatAssertion},
+	"https://json-schema.org/draft/2020-12/meta/content":           {VersionDraft2020, SchemaDraft2020Content},
+}
+
+// MetaSchemaURIs returns the canonical URIs of all the embedded meta-schemas.
+func MetaSchemaURIs() []string {
+	return std.SortedKeys(metaSchemas)
+}
+
+// IsMetaSchema returns whether the URI is the canonical URI of an embedded meta-schema, where an empty fragment is allowed.
+func IsMetaSchema(uri string) bool {
+	_, ok := metaSchemas[strings.TrimSuffix(uri, "#")]
+	return ok
+}
+
+// ParseMetaSchema parses the embedded meta-schema with the canonical URI.
+func ParseMetaSchema(uri string) (*Schema, error) {
+	m, ok := metaSchemas[strings.TrimSuffix(uri, "#")]
+	if !ok {
+		return nil, fmt.Errorf("unknown meta-schema %s", uri)
+	}
+	s, err := ParseSchema([]byte(m.schema))
+	if err != nil {
+		return nil, err
+	}
+	s.SetDefaultVersion(m.version)
+	return s, nil
+}
+
+// Keywords returns the keywords that are defined by the embedded meta-schemas of the version, including the vocabulary meta-schemas.
+// $ref is always a keyword, even though the Draft 4 meta-schema does not define it.
+// There is no embedded meta-schema for Draft 3, so it only returns $ref for Draft 3.
+func Keywords(v Version) (map[string]bool, error) {
+	keywords := map[string]bool{"$ref": true}
+	for _, uri := range MetaSchemaURIs() {
+		if metaSchemas[uri].version != v {
+			continue
+		}
+		var m struct {
+			Properties map[string]any `json:"properties"`
+		}
+		if err := std.UnmarshalJSON([]byte(metaSchemas[uri].schema), &m); err != nil {
+			return nil, err
+		}
+		for keyword := range m.Properties {
+			keywords[keyword] = true
+		}
+	}
+	return keywords, nil
+}
+
+// MetaSchemaURI returns the canonical URI of the meta-schema of the version, where the latest version is Draft 2020-12.
+func MetaSchemaURI(v Version) string {
+	switch v {
+	case VersionDraft4:
+		return "http://json-schema.org/draft-04/schema"
+	case VersionDraft6:
+		return "http://json-schema.org/draft-06/schema"
+	case VersionDraft7:
+		return "http://json-schema.org/draft-07/schema"
+	case VersionDraft2019:
+		return "https://json-schema.org/draft/2019-09/schema"
+	}
+	return "https://json-schema.org/draft/2020-12/schema"
+}
+
+// DetectVersion returns the version of the schema from its $schema keyword, without parsing the rest of the schema.
+// It returns the default version if $schema is not present or unknown.
+func DetectVersion(schemaStr []byte, defaultVersion Version) Version {
+	var s struct {
+		Schema string `json:"$schema"`
+	}
+	if err := std.UnmarshalJSON(schemaStr, &s); err != nil {
+		return defaultVersion
+	}
+	if v := lookupVersion(s.Schema); v != VersionUnknown {
+		return v
+	}
+	return defaultVersion
+}
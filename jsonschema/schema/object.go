@@ -37,12 +37,16 @@ type Object struct {
 	//  The value of "patternProperties" MUST be an object. Each property name of this object SHOULD be a valid regular expression, according to the ECMA 262 regular expression dialect. Each property value of this object MUST be an object, and each object MUST be a valid JSON Schema.
 	PatternProperties map[string]*Schema `json:"patternProperties,omitempty"`
 	PropertyNames     *Schema            `json:"propertyNames,omitempty"`
+	// UnevaluatedProperties is supported since Draft 2019-09.
+	// It applies to all properties that were not evaluated by this schema or any of its in-place applicators.
+	UnevaluatedProperties *Schema `json:"unevaluatedProperties,omitempty"`
 }
 
 func (this Object) HasObjectConstraints() bool {
 	return this.MaxProperties != nil || this.MinProperties > 0 ||
 		this.Required != nil || this.AdditionalProperties != nil ||
-		this.Properties != nil || this.PatternProperties != nil
+		this.Properties != nil || this.PatternProperties != nil ||
+		this.PropertyNames != nil
 }
 
 func (this Object) GetAdditionalProperties() *Additional {
@@ -68,6 +72,7 @@ type Properties map[string]*Schema
 */
 //http://json-schema.org/latest/json-schema-validation.html#anchor64
 //  The value of "properties" MUST be an object. Each value of this object MUST be an object, and each object MUST be a valid JSON Schema.
+// Since Draft 6 a value can also be a boolean schema.
 // But sometimes it isn't and then we can ignore those values.
 func (this *Properties) UnmarshalJSON(data []byte) error {
 	var objmap map[string]json.RawMessage
@@ -37,26 +37,91 @@ func (this *Schema) JsonString() string {
 }
 
 type Schema struct {
-	Id          string `json:"id,omitempty"`
-	Anchor      string `json:"$anchor,omitempty"`
-	Schema      string `json:"$schema,omitempty"`
-	Title       string `json:"title,omitempty"`
-	Description string `json:"description,omitempty"`
-	Default     any    `json:"default,omitempty"`
+	// Bool is set if the schema is a boolean schema, which is supported since Draft 6.
+	// true is equivalent to the empty schema {} and false matches nothing.
+	Bool *bool `json:"-"`
+
+	Id       string `json:"id,omitempty"`
+	DollarId string `json:"$id,omitempty"`
+	Anchor   string `json:"$anchor,omitempty"`
+	// DynamicAnchor is supported since Draft 2020-12 and marks a schema that a $dynamicRef can resolve to.
+	DynamicAnchor string `json:"$dynamicAnchor,omitempty"`
+	// RecursiveAnchor is only supported in Draft 2019-09 and marks the root of a schema resource that a $recursiveRef can resolve to.
+	RecursiveAnchor bool   `json:"$recursiveAnchor,omitempty"`
+	Schema          string `json:"$schema,omitempty"`
+	// Vocabulary is supported since Draft 2019-09 and is only used by meta-schemas to declare the vocabularies of their dialect.
+	Vocabulary map[string]bool `json:"$vocabulary,omitempty"`
+	// Dialect is set if $schema is not the URI of an official meta-schema, otherwise the dialect is detected from $schema.
+	Dialect     *Dialect `json:"-"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Default     any      `json:"default,omitempty"`
+	// HasDefault is set if default is present, since a default of null is nil.
+	HasDefault bool `json:"-"`
+	// Examples is supported since Draft 6.
+	Examples []any `json:"examples,omitempty"`
+	// Deprecated is supported since Draft 2019-09.
+	Deprecated bool `json:"deprecated,omitempty"`
 
 	//  This keyword's value MUST be an object. Each member value of this object MUST be a valid JSON Schema.
 	Definitions map[string]*Schema `json:"definitions,omitempty"`
+	// Defs replaces Definitions since Draft 2019-09, but both are supported as definition containers.
+	Defs map[string]*Schema `json:"$defs,omitempty"`
 
 	Numeric
 	String
 	Array
 	Object
 	Operators
+	Draft3
 	Type *Type `json:"type,omitempty"`
 	// Const is *any because a JSON null (Go nil) is a valid value.
 	Const Const `json:"const,omitempty"`
 
 	Ref string `json:"$ref,omitempty"`
+	// DynamicRef is supported since Draft 2020-12.
+	// If it resolves to a $dynamicAnchor, then it resolves to the outermost schema resource in the dynamic scope that declares the same $dynamicAnchor.
+	DynamicRef string `json:"$dynamicRef,omitempty"`
+	// RecursiveRef is only supported in Draft 2019-09, where it was replaced by DynamicRef in Draft 2020-12.
+	// If it resolves to a schema with $recursiveAnchor set to true, then it resolves to the outermost schema resource in the dynamic scope that also sets $recursiveAnchor to true.
+	RecursiveRef string `json:"$recursiveRef,omitempty"`
+}
+
+// schemaFields has the same fields as Schema, but not its methods, which avoids recursion when unmarshaling.
+type schemaFields Schema
+
+func (this *Schema) UnmarshalJSON(buf []byte) error {
+	var b bool
+	if err := std.UnmarshalJSON(buf, &b); err == nil {
+		*this = Schema{Bool: &b}
+		return nil
+	}
+	if err := this.unmarshalFields(buf); err != nil {
+		return this.unmarshalDraft3(buf, err)
+	}
+	return nil
+}
+
+// noDefault is the default before unmarshaling, which is only replaced if default is present, even if it is null.
+type noDefault struct{}
+
+// unmarshalFields unmarshals the fields of the schema and sets HasDefault.
+func (this *Schema) unmarshalFields(buf []byte) error {
+	this.Default = noDefault{}
+	err := std.UnmarshalJSON(buf, (*schemaFields)(this))
+	if _, ok := this.Default.(noDefault); ok {
+		this.Default = nil
+	} else {
+		this.HasDefault = true
+	}
+	return err
+}
+
+func (this *Schema) MarshalJSON() ([]byte, error) {
+	if this.Bool != nil {
+		return json.Marshal(*this.Bool)
+	}
+	return json.Marshal((*schemaFields)(this))
 }
 
 func (this Schema) GetType() []SimpleType {
@@ -66,8 +131,28 @@ func (this Schema) GetType() []SimpleType {
 	return nil
 }
 
+// GetId returns "id" for Draft 3 and 4 and "$id" for all later versions.
+func (this Schema) GetId() string {
+	if this.GetVersion() <= VersionDraft4 {
+		return this.Id
+	}
+	return this.DollarId
+}
+
+// SetId sets "id" for Draft 3 and 4 and "$id" for all later versions.
+func (this *Schema) SetId(id string) {
+	if this.GetVersion() <= VersionDraft4 {
+		this.Id = id
+	} else {
+		this.DollarId = id
+	}
+}
+
 func (this Schema) GetVersion() Version {
-	return detectVersion(this.Schema)
+	if this.Dialect != nil {
+		return this.Dialect.Version
+	}
+	return lookupVersion(this.Schema)
 }
 
 func (this *Schema) SetDefaultVersion(defaultVersion Version) {
@@ -20,8 +20,17 @@ type String struct {
 	MinLength uint64  `json:"minLength,omitempty"`
 	Pattern   *string `json:"pattern,omitempty"`
 	Format    string  `json:"format,omitempty"`
+	// ContentEncoding, ContentMediaType and ContentSchema are supported since Draft 7, but ContentSchema only since Draft 2019-09.
+	// They are only annotations, unless content assertion is enabled.
+	ContentEncoding  string  `json:"contentEncoding,omitempty"`
+	ContentMediaType string  `json:"contentMediaType,omitempty"`
+	ContentSchema    *Schema `json:"contentSchema,omitempty"`
 }
 
 func (this String) HasStringConstraints() bool {
 	return this.MaxLength != nil || this.MinLength > 0 || this.Pattern != nil || len(this.Format) > 0
 }
+
+func (this String) HasContentConstraints() bool {
+	return len(this.ContentEncoding) > 0 || len(this.ContentMediaType) > 0
+}
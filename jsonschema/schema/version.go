@@ -22,6 +22,7 @@ import (
 type Version int
 
 const VersionUnknown = 0
+const VersionDraft3 = 3
 const VersionDraft4 = 4
 const VersionDraft6 = 6
 const VersionDraft7 = 7
@@ -32,10 +33,16 @@ const VersionLatest = math.MaxInt
 var strToVersion = map[string]Version{
 	"https://json-schema.org/schema":               VersionLatest,
 	"https://json-schema.org/draft/2020-12/schema": VersionDraft2020,
-	"http://json-schema.org/draft/2019-09/schema":  VersionDraft2019,
+	"https://json-schema.org/draft/2019-09/schema": VersionDraft2019,
 	"http://json-schema.org/draft-07/schema":       VersionDraft7,
 	"http://json-schema.org/draft-06/schema":       VersionDraft6,
 	"http://json-schema.org/draft-04/schema":       VersionDraft4,
+	"http://json-schema.org/draft-03/schema":       VersionDraft3,
+}
+
+// versionAliases are URIs that are also detected as a version, but that are not the URI of its meta-schema.
+var versionAliases = map[string]Version{
+	"http://json-schema.org/draft/2019-09/schema": VersionDraft2019,
 }
 
 var versionToStr = map[Version]string{}
@@ -49,14 +56,31 @@ func init() {
 func detectVersion(url string) Version {
 	u := strings.Split(url, "#")[0]
 	strings.Replace(u, "http://", "https://", 1)
-	return strToVersion[u]
+	if v, ok := strToVersion[u]; ok {
+		return v
+	}
+	return versionAliases[u]
 }
 
+// setDefaultVersion sets $schema where it is missing, to the $schema of the closest parent or otherwise to the default version,
+// which means that a subschema with its own $schema, for example an embedded resource, is interpreted entirely under its own dialect.
+// A $schema that is not the URI of an official meta-schema is kept, since it can refer to a custom dialect,
+// but until that dialect is resolved, it is interpreted as the default version.
 func setDefaultVersion(s *Schema, defaultVersion Version) {
-	defaultVersionStr := versionToStr[defaultVersion]
-	s.Walk(func(sch *Schema) {
-		if detectVersion(sch.Schema) == VersionUnknown {
-			sch.Schema = defaultVersionStr
+	if len(s.Schema) == 0 {
+		s.Schema = versionToStr[defaultVersion]
+	}
+	inheritVersion(s, defaultVersion)
+}
+
+func inheritVersion(s *Schema, defaultVersion Version) {
+	if lookupVersion(s.Schema) == VersionUnknown && s.Dialect == nil {
+		s.Dialect = &Dialect{Version: defaultVersion}
+	}
+	for _, child := range s.children() {
+		if len(child.Schema) == 0 {
+			child.Schema = s.Schema
 		}
-	})
+		inheritVersion(child, defaultVersion)
+	}
 }
@@ -0,0 +1,197 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+)
+
+// Vocabulary is a set of keywords, which a dialect can enable with $vocabulary since Draft 2019-09.
+type Vocabulary string
+
+const VocabularyCore Vocabulary = "core"
+const VocabularyApplicator Vocabulary = "applicator"
+
+// VocabularyUnevaluated is part of VocabularyApplicator in Draft 2019-09.
+const VocabularyUnevaluated Vocabulary = "unevaluated"
+const VocabularyValidation Vocabulary = "validation"
+const VocabularyMetaData Vocabulary = "meta-data"
+
+// VocabularyFormatAnnotation makes format only an annotation.
+const VocabularyFormatAnnotation Vocabulary = "format-annotation"
+
+// VocabularyFormatAssertion makes format a hard check.
+const VocabularyFormatAssertion Vocabulary = "format-assertion"
+const VocabularyContent Vocabulary = "content"
+
+// vocabularyURIs maps the URIs of the official vocabularies to vocabularies.
+var vocabularyURIs = map[string]Vocabulary{
+	"https://json-schema.org/draft/2019-09/vocab/core":       VocabularyCore,
+	"https://json-schema.org/draft/2019-09/vocab/applicator": VocabularyApplicator,
+	"https://json-schema.org/draft/2019-09/vocab/validation": VocabularyValidation,
+	"https://json-schema.org/draft/2019-09/vocab/meta-data":  VocabularyMetaData,
+	"https://json-schema.org/draft/2019-09/vocab/format":     VocabularyFormatAnnotation,
+	"https://json-schema.org/draft/2019-09/vocab/content":    VocabularyContent,
+
+	"https://json-schema.org/draft/2020-12/vocab/core":              VocabularyCore,
+	"https://json-schema.org/draft/2020-12/vocab/applicator":        VocabularyApplicator,
+	"https://json-schema.org/draft/2020-12/vocab/unevaluated":       VocabularyUnevaluated,
+	"https://json-schema.org/draft/2020-12/vocab/validation":        VocabularyValidation,
+	"https://json-schema.org/draft/2020-12/vocab/meta-data":         VocabularyMetaData,
+	"https://json-schema.org/draft/2020-12/vocab/format-annotation": VocabularyFormatAnnotation,
+	"https://json-schema.org/draft/2020-12/vocab/format-assertion":  VocabularyFormatAssertion,
+	"https://json-schema.org/draft/2020-12/vocab/content":           VocabularyContent,
+}
+
+// Dialect is what a $schema URI refers to, which is a version and the vocabularies that are enabled.
+type Dialect struct {
+	Version Version
+	// Vocabularies are the enabled vocabularies, where nil enables all the vocabularies of the version.
+	// If all the vocabularies are enabled, then format is a hard check, otherwise only VocabularyFormatAssertion makes format a hard check.
+	Vocabularies []Vocabulary
+}
+
+// HasVocabulary returns whether the vocabulary is enabled.
+func (d *Dialect) HasVocabulary(v Vocabulary) bool {
+	if d.Vocabularies == nil {
+		return true
+	}
+	return v == VocabularyCore || slices.Contains(d.Vocabularies, v)
+}
+
+// LookupDialect returns the dialect of an official meta-schema URI, which enables all the vocabularies of its version.
+func LookupDialect(uri string) (*Dialect, bool) {
+	v := lookupVersion(uri)
+	if v == VersionUnknown {
+		return nil, false
+	}
+	return &Dialect{Version: v}, true
+}
+
+// lookupVersion returns the version of an official meta-schema URI, otherwise it returns VersionUnknown.
+func lookupVersion(uri string) Version {
+	if v := detectVersion(uri); v != VersionUnknown {
+		return v
+	}
+	if m, ok := metaSchemas[strings.TrimSuffix(uri, "#")]; ok {
+		return m.version
+	}
+	return VersionUnknown
+}
+
+// NewDialect returns the dialect that the meta-schema describes, where the version is the version of the meta-schema and the vocabularies are from its $vocabulary.
+// Vocabularies are only supported since Draft 2019-09 and without $vocabulary all the vocabularies are enabled.
+// An unknown vocabulary returns an error if it is required, which is when its value is true, otherwise it is ignored.
+func NewDialect(metaSchema *Schema) (*Dialect, error) {
+	d := &Dialect{Version: metaSchema.GetVersion()}
+	if d.Version < VersionDraft2019 || metaSchema.Vocabulary == nil {
+		return d, nil
+	}
+	d.Vocabularies = []Vocabulary{}
+	for _, uri := range std.SortedKeys(metaSchema.Vocabulary) {
+		required := metaSchema.Vocabulary[uri]
+		v, ok := vocabularyURIs[uri]
+		if !ok {
+			if required {
+				return nil, fmt.Errorf("unsupported vocabulary %s is required by meta-schema %s", uri, metaSchema.GetId())
+			}
+			continue
+		}
+		if v == VocabularyFormatAnnotation && required && d.Version == VersionDraft2019 {
+			// In Draft 2019-09 a required format vocabulary makes format a hard check.
+			v = VocabularyFormatAssertion
+		}
+		d.Vocabularies = append(d.Vocabularies, v)
+	}
+	return d, nil
+}
+
+// ApplyVocabularies removes the keywords of the vocabularies that are not enabled by the dialect of each subschema,
+// since keywords of vocabularies that are not enabled are ignored.
+func (s *Schema) ApplyVocabularies() {
+	s.Walk(func(sch *Schema) {
+		if sch.Dialect != nil {
+			sch.Dialect.removeKeywords(sch)
+		}
+	})
+}
+
+func (d *Dialect) removeKeywords(s *Schema) {
+	if !d.HasVocabulary(VocabularyApplicator) {
+		s.Items = nil
+		s.PrefixItems = nil
+		s.Contains = nil
+		s.AdditionalProperties = nil
+		s.Properties = nil
+		s.PatternProperties = nil
+		s.DependentSchemas = nil
+		s.PropertyNames = nil
+		s.If = nil
+		s.Then = nil
+		s.Else = nil
+		s.AllOf = nil
+		s.AnyOf = nil
+		s.OneOf = nil
+		s.Not = nil
+		if d.Version == VersionDraft2019 {
+			s.AdditionalItems = nil
+			s.UnevaluatedItems = nil
+			s.UnevaluatedProperties = nil
+		}
+	}
+	if !d.HasVocabulary(VocabularyUnevaluated) && d.Version >= VersionDraft2020 {
+		s.UnevaluatedItems = nil
+		s.UnevaluatedProperties = nil
+	}
+	if !d.HasVocabulary(VocabularyValidation) {
+		s.Type = nil
+		s.Const = Const{}
+		s.Enum = nil
+		s.Numeric = Numeric{}
+		s.MaxLength = nil
+		s.MinLength = 0
+		s.Pattern = nil
+		s.MaxItems = nil
+		s.MinItems = 0
+		s.UniqueItems = false
+		s.MaxContains = nil
+		s.MinContains = nil
+		s.MaxProperties = nil
+		s.MinProperties = 0
+		s.Required = nil
+		s.DependentRequired = nil
+	}
+	if !d.HasVocabulary(VocabularyMetaData) {
+		s.Title = ""
+		s.Description = ""
+		s.Default = nil
+		s.HasDefault = false
+		s.Examples = nil
+		s.Deprecated = false
+	}
+	if !d.HasVocabulary(VocabularyFormatAssertion) {
+		// format is only an annotation
+		s.Format = ""
+	}
+	if !d.HasVocabulary(VocabularyContent) {
+		s.ContentEncoding = ""
+		s.ContentMediaType = ""
+		s.ContentSchema = nil
+	}
+}
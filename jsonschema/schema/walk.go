@@ -14,58 +14,99 @@
 
 package schema
 
+// Walk visits the schema and then walks all its subschemas.
 func (s *Schema) Walk(visit func(s *Schema)) {
 	visit(s)
-	for _, child := range s.Definitions {
+	for _, child := range s.children() {
 		child.Walk(visit)
 	}
+}
+
+// children returns the direct subschemas of the schema.
+func (s *Schema) children() []*Schema {
+	var children []*Schema
+	for _, child := range s.Definitions {
+		children = append(children, child)
+	}
+	for _, child := range s.Defs {
+		children = append(children, child)
+	}
 	if child := s.Array.GetAdditionalItems().GetSchema(); child != nil {
-		child.Walk(visit)
+		children = append(children, child)
 	}
 	if child := s.Array.GetItems().GetObject(); child != nil {
-		child.Walk(visit)
+		children = append(children, child)
 	}
 	for _, child := range s.Array.GetItems().GetArray() {
-		child.Walk(visit)
+		children = append(children, child)
+	}
+	for _, child := range s.Array.PrefixItems {
+		children = append(children, child)
+	}
+	if child := s.Array.Contains; child != nil {
+		children = append(children, child)
+	}
+	if child := s.Array.UnevaluatedItems; child != nil {
+		children = append(children, child)
+	}
+	if child := s.String.ContentSchema; child != nil {
+		children = append(children, child)
 	}
 	if child := s.Object.GetAdditionalProperties().GetSchema(); child != nil {
-		child.Walk(visit)
+		children = append(children, child)
 	}
 	for _, child := range s.Object.GetProperties() {
-		child.Walk(visit)
+		children = append(children, child)
 	}
 	for _, child := range s.Object.GetPatternProperties() {
-		child.Walk(visit)
+		children = append(children, child)
+	}
+	if child := s.Object.PropertyNames; child != nil {
+		children = append(children, child)
+	}
+	if child := s.Object.UnevaluatedProperties; child != nil {
+		children = append(children, child)
 	}
 	for _, child := range s.Operators.AllOf {
-		child.Walk(visit)
+		children = append(children, child)
 	}
 	for _, child := range s.Operators.AnyOf {
-		child.Walk(visit)
+		children = append(children, child)
 	}
 	for _, child := range s.Operators.OneOf {
-		child.Walk(visit)
+		children = append(children, child)
 	}
 	if child := s.Operators.Not; child != nil {
-		child.Walk(visit)
+		children = append(children, child)
 	}
 	if child := s.Operators.If; child != nil {
-		child.Walk(visit)
+		children = append(children, child)
 	}
 	if child := s.Operators.Then; child != nil {
-		child.Walk(visit)
+		children = append(children, child)
 	}
 	if child := s.Operators.Else; child != nil {
-		child.Walk(visit)
+		children = append(children, child)
 	}
 	if deps := s.Operators.Dependencies; deps != nil {
 		for _, dep := range *deps {
 			if child := dep.Schema; child != nil {
-				child.Walk(visit)
+				children = append(children, child)
 			}
 		}
 	}
 	for _, child := range s.Operators.DependentSchemas {
-		child.Walk(visit)
+		children = append(children, child)
+	}
+	if child := s.Draft3.Extends.GetObject(); child != nil {
+		children = append(children, child)
+	}
+	children = append(children, s.Draft3.Extends.GetArray()...)
+	if s.Draft3.Disallow != nil {
+		children = append(children, s.Draft3.Disallow.Schemas...)
+	}
+	if s.Draft3.TypeUnion != nil {
+		children = append(children, s.Draft3.TypeUnion.Schemas...)
 	}
+	return children
 }
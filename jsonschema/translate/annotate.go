@@ -0,0 +1,143 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"strconv"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/output"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+)
+
+// Annotate returns the annotations that apply to each location in the value, where the locations are json pointers.
+// The annotations are title, description, default, examples and deprecated,
+// which are collected through references, allOf and the subschemas of anyOf, oneOf and if that match.
+// The value is expected to match the schema, since the annotations of schemas that do not match are dropped.
+// The annotations of subschemas that are only applied by unevaluatedProperties or unevaluatedItems are not collected.
+func (e *Explainer) Annotate(value []byte) (map[string]*output.Annotations, error) {
+	var v any
+	if err := std.UnmarshalJSON(value, &v); err != nil {
+		return nil, err
+	}
+	annotations := make(map[string]*output.Annotations)
+	sc := explainScope{base: e.t.rootId}
+	if err := e.annotate(sc, e.root, v, annotations, make(map[*schema.Schema]bool)); err != nil {
+		return nil, err
+	}
+	return annotations, nil
+}
+
+// annotate adds the annotations of the schema and of the subschemas that apply to the value, which matches the schema.
+// visited contains the schemas that already applied to this value, which stops references and allOf that loop without consuming any of the value.
+func (e *Explainer) annotate(sc explainScope, s *schema.Schema, value any, annotations map[string]*output.Annotations, visited map[*schema.Schema]bool) error {
+	if s.Bool != nil || visited[s] {
+		return nil
+	}
+	visited[s] = true
+	if len(s.Ref) == 0 || s.GetVersion() > schema.VersionDraft7 {
+		// before draft version 7 ref silently ignores siblings
+		addAnnotations(annotations, sc.instanceLocation, s)
+	}
+	sc = e.enterId(sc, s)
+	applyToValue := func(sc explainScope, s *schema.Schema, value any) error {
+		return e.annotate(sc, s, value, annotations, visited)
+	}
+	applyToParts := func(sc explainScope, s *schema.Schema, value any) error {
+		return e.annotate(sc, s, value, annotations, make(map[*schema.Schema]bool))
+	}
+	for _, keyword := range keywordsOf(s) {
+		switch keyword {
+		case "$ref", "$dynamicRef", "$recursiveRef", "dependencies", "dependentSchemas", "allOf", "extends":
+			// these subschemas all match, since the schema matches.
+			if err := e.applySubschemas(sc.keyword(keyword), s, keyword, value, applyToValue); err != nil {
+				return err
+			}
+		case "properties", "patternProperties", "additionalProperties", "items", "prefixItems", "additionalItems":
+			// these subschemas all match the parts of the value that they apply to, since the schema matches.
+			if err := e.applySubschemas(sc.keyword(keyword), s, keyword, value, applyToParts); err != nil {
+				return err
+			}
+		case "anyOf", "oneOf":
+			schs := s.AnyOf
+			if keyword == "oneOf" {
+				schs = s.OneOf
+			}
+			for i, sch := range schs {
+				if _, err := e.annotateIfMatch(sc.keyword(keyword, strconv.Itoa(i)), sch, value, annotations, visited); err != nil {
+					return err
+				}
+			}
+		case "contains":
+			arr, _ := value.([]any)
+			for i, item := range arr {
+				if _, err := e.annotateIfMatch(sc.keyword(keyword).instance(strconv.Itoa(i)), s.Contains, item, annotations, make(map[*schema.Schema]bool)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if s.If == nil || s.GetVersion() < schema.VersionDraft7 || (len(s.Ref) > 0 && s.GetVersion() <= schema.VersionDraft7) {
+		return nil
+	}
+	matched, err := e.annotateIfMatch(sc.keyword("if"), s.If, value, annotations, visited)
+	if err != nil {
+		return err
+	}
+	if matched && s.Then != nil {
+		return e.annotate(sc.keyword("then"), s.Then, value, annotations, visited)
+	}
+	if !matched && s.Else != nil {
+		return e.annotate(sc.keyword("else"), s.Else, value, annotations, visited)
+	}
+	return nil
+}
+
+// annotateIfMatch adds the annotations of the schema, only if the value matches the schema, and returns whether it matches.
+func (e *Explainer) annotateIfMatch(sc explainScope, s *schema.Schema, value any, annotations map[string]*output.Annotations, visited map[*schema.Schema]bool) (bool, error) {
+	matched, err := e.match(sc.parentId, sc.bindings, s, value)
+	if err != nil || !matched {
+		return false, err
+	}
+	return true, e.annotate(sc, s, value, annotations, visited)
+}
+
+// addAnnotations adds the annotations of the schema to the annotations of the location.
+func addAnnotations(annotations map[string]*output.Annotations, location string, s *schema.Schema) {
+	var examples []any
+	if s.GetVersion() >= schema.VersionDraft6 {
+		examples = s.Examples
+	}
+	deprecated := s.Deprecated && s.GetVersion() >= schema.VersionDraft2019
+	if len(s.Title) == 0 && len(s.Description) == 0 && !s.HasDefault && len(examples) == 0 && !deprecated {
+		return
+	}
+	a, ok := annotations[location]
+	if !ok {
+		a = &output.Annotations{}
+		annotations[location] = a
+	}
+	if len(s.Title) > 0 {
+		a.Titles = append(a.Titles, s.Title)
+	}
+	if len(s.Description) > 0 {
+		a.Descriptions = append(a.Descriptions, s.Description)
+	}
+	if s.HasDefault {
+		a.Defaults = append(a.Defaults, s.Default)
+	}
+	a.Examples = append(a.Examples, examples...)
+	a.Deprecated = a.Deprecated || deprecated
+}
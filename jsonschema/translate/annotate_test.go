@@ -0,0 +1,44 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+func TestAnnotateRecursiveSchema(t *testing.T) {
+	schemas := []string{
+		`{"$ref": "#", "title": "root"}`,
+		`{"allOf": [{"$ref": "#"}], "title": "root"}`,
+	}
+	for _, schemaStr := range schemas {
+		t.Run(schemaStr, func(t *testing.T) {
+			e, err := NewExplainer([]byte(schemaStr), schema.VersionDraft2020)
+			if err != nil {
+				t.Fatal(err)
+			}
+			annotations, err := e.Annotate([]byte(`{"a": 1}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			a := annotations[""]
+			if a == nil || len(a.Titles) != 1 || a.Titles[0] != "root" {
+				t.Fatalf("want the title once, but got %v", a)
+			}
+		})
+	}
+}
@@ -22,9 +22,10 @@ import (
 	"github.com/katydid/validator-go/validator/ast"
 )
 
-func translateArray(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+func (t *translator) translateArray(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 	constraints := []*ast.Pattern{}
-	if s.UniqueItems {
+	if s.UniqueItems && t.options.uniqueItems == nil {
+		// uniqueItems can only be validated after the automaton, see decisions/uniqueItems.md
 		return nil, fmt.Errorf("uniqueItems are not supported")
 	}
 	if s.MaxItems != nil {
@@ -33,6 +34,36 @@ func translateArray(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 	if s.MinItems > 0 {
 		constraints = append(constraints, minItems(int(s.MinItems)))
 	}
+	var items *ast.Pattern
+	var err error
+	if s.GetVersion() >= schema.VersionDraft2020 {
+		items, err = t.translatePrefixItems(parentId, s)
+	} else {
+		items, err = t.translateItems(parentId, s)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if items != nil {
+		constraints = append(constraints, items)
+	}
+	if s.Contains != nil && s.GetVersion() >= schema.VersionDraft6 {
+		contains, err := t.translateContains(parentId, s)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, contains)
+	}
+	if len(constraints) == 0 {
+		return ast.NewZAny(), nil
+	}
+	return newAnd(constraints...), nil
+}
+
+// translateItems translates items and additionalItems as they are specified before Draft 2020-12.
+// If items is an array, then additionalItems applies to the rest of the items.
+// It returns nil if there are no item constraints.
+func (t *translator) translateItems(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 	additionalItems := ast.NewZAny()
 	if s.AdditionalItems != nil {
 		if s.AdditionalItems.Bool != nil {
@@ -41,31 +72,83 @@ func translateArray(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 			}
 		}
 		if s.AdditionalItems.Schema != nil {
-			p, err := translate(getId(parentId, s), s.AdditionalItems.Schema)
+			p, err := t.translate(getId(parentId, s), s.AdditionalItems.Schema)
 			if err != nil {
 				return nil, err
 			}
 			additionalItems = ast.NewZeroOrMore(anyIndex(p))
 		}
 	}
-	if s.Items != nil {
-		if s.Items.Object != nil {
-			sch := s.Items.Object
-			pattern, err := translate(getId(parentId, s), sch)
-			if err != nil {
-				return nil, err
-			}
-			constraints = append(constraints, ast.NewZeroOrMore(anyIndex(pattern)))
-		} else if s.Items.Array != nil {
-			schs := s.Items.Array
-			patterns, err := std.MapErr(schs, translateWithParentId(getId(parentId, s)))
-			if err != nil {
-				return nil, err
-			}
-			patterns = std.Map(patterns, anyIndex)
-			patterns = concatCombos(patterns, additionalItems)
-			constraints = append(constraints, newOr(patterns...))
+	if s.Items == nil {
+		return nil, nil
+	}
+	if s.Items.Object != nil {
+		sch := s.Items.Object
+		pattern, err := t.translate(getId(parentId, s), sch)
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewZeroOrMore(anyIndex(pattern)), nil
+	}
+	if s.Items.Array != nil {
+		return t.translateTuple(parentId, s, s.Items.Array, additionalItems)
+	}
+	return nil, nil
+}
+
+// translatePrefixItems translates prefixItems and items as they are specified since Draft 2020-12.
+// If prefixItems is present, then items applies to the rest of the items.
+// It returns nil if there are no item constraints.
+func (t *translator) translatePrefixItems(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+	if s.Items.GetArray() != nil {
+		return nil, fmt.Errorf("items must be a schema since draft 2020-12, use prefixItems for an array of schemas")
+	}
+	rest := ast.NewZAny()
+	if sch := s.Items.GetObject(); sch != nil {
+		p, err := t.translate(getId(parentId, s), sch)
+		if err != nil {
+			return nil, err
 		}
+		rest = ast.NewZeroOrMore(anyIndex(p))
+	}
+	if len(s.PrefixItems) == 0 {
+		if s.Items.GetObject() == nil {
+			return nil, nil
+		}
+		return rest, nil
+	}
+	return t.translateTuple(parentId, s, s.PrefixItems, rest)
+}
+
+// translateTuple translates an array of schemas that each apply to the item at the same index, followed by the rest of the items.
+func (t *translator) translateTuple(parentId string, s *schema.Schema, schs []*schema.Schema, rest *ast.Pattern) (*ast.Pattern, error) {
+	patterns, err := std.MapErr(schs, t.translateWithParentId(getId(parentId, s)))
+	if err != nil {
+		return nil, err
+	}
+	patterns = std.Map(patterns, anyIndex)
+	patterns = concatCombos(patterns, rest)
+	return newOr(patterns...), nil
+}
+
+// translateContains translates contains, which requires at least one item to match the contains schema.
+// Since Draft 2019-09 minContains and maxContains limit the number of items that match.
+func (t *translator) translateContains(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+	p, err := t.translate(getId(parentId, s), s.Contains)
+	if err != nil {
+		return nil, err
+	}
+	if s.GetVersion() < schema.VersionDraft2019 || (s.MinContains == nil && s.MaxContains == nil) {
+		return ast.NewContains(anyIndex(p)), nil
+	}
+	constraints := []*ast.Pattern{}
+	if s.MaxContains != nil {
+		constraints = append(constraints, maxContains(int(*s.MaxContains), p))
+	}
+	if s.MinContains == nil {
+		constraints = append(constraints, ast.NewContains(anyIndex(p)))
+	} else if *s.MinContains > 0 {
+		constraints = append(constraints, minContains(int(*s.MinContains), p))
 	}
 	if len(constraints) == 0 {
 		return ast.NewZAny(), nil
@@ -111,3 +194,17 @@ func minItems(n int) *ast.Pattern {
 	}
 	return ast.NewConcat(ast.NewConcat(ps...), ast.NewZAny())
 }
+
+func maxContains(n int, p *ast.Pattern) *ast.Pattern {
+	// one more than the maxContains
+	return ast.NewNot(minContains(n+1, p))
+}
+
+func minContains(n int, p *ast.Pattern) *ast.Pattern {
+	ps := make([]*ast.Pattern, 0, 2*n+1)
+	ps = append(ps, ast.NewZAny())
+	for i := 0; i < n; i++ {
+		ps = append(ps, anyIndex(p.Clone()), ast.NewZAny())
+	}
+	return ast.NewConcat(ps...)
+}
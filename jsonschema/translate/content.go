@@ -0,0 +1,255 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/katydid/parser-go-json/json"
+	contentfuncs "github.com/katydid/validator-go-jsonschema/jsonschema/funcs"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+	"github.com/katydid/validator-go/validator/ast"
+	"github.com/katydid/validator-go/validator/combinator"
+	"github.com/katydid/validator-go/validator/funcs"
+	"github.com/katydid/validator-go/validator/intern"
+)
+
+// translateContent translates contentEncoding, contentMediaType and contentSchema, which only apply to strings.
+// The contentSchema is validated by a nested grammar, since the decoded content is not part of the parsed value.
+// The contentSchema is translated like any other subschema, so that it can refer to the definitions of the schema.
+// Its grammar is only complete once all definitions are translated, so the content function refers to it by a key, see registerContents.
+func (t *translator) translateContent(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+	key := ""
+	if s.ContentSchema != nil && s.GetVersion() >= schema.VersionDraft2019 {
+		p, err := t.translate(getId(parentId, s), s.ContentSchema)
+		if err != nil {
+			return nil, err
+		}
+		key = newContentKey()
+		if t.contents == nil {
+			t.contents = make(map[string]*ast.Pattern)
+		}
+		t.contents[key] = p
+	}
+	expr := contentExpr(s.ContentEncoding, s.ContentMediaType, key)
+	return newOr(combinator.Value(expr), notStringType()), nil
+}
+
+// contentRoot is the name of the root schema in the nested grammar of a contentSchema, which starts with the contentSchema as main.
+const contentRoot = "content root"
+
+var (
+	contentKeys atomic.Uint64
+	contentMu   sync.Mutex
+	// contentGrammars maps the keys of the content functions to the nested grammars of their contentSchemas.
+	// The keys are removed once the grammar or explainer that owns them is garbage collected, see bindContents.
+	contentGrammars = make(map[string]*ast.Grammar)
+)
+
+func newContentKey() string {
+	return "content " + strconv.FormatUint(contentKeys.Add(1), 10)
+}
+
+// registerContents registers the nested grammars of the contentSchemas that were translated since the last registration and returns their keys.
+// refs are all the translated definitions and root is the name of the root schema in refs.
+// Each nested grammar consists of these definitions, where the root schema is renamed to contentRoot, and starts with its contentSchema.
+func (t *translator) registerContents(refs map[string]*ast.Pattern, root string) []string {
+	if len(t.contents) == 0 {
+		return nil
+	}
+	nested := make(map[string]*ast.Pattern, len(refs))
+	for name, p := range refs {
+		if name == root {
+			name = contentRoot
+		}
+		nested[name] = renameRoot(p.Clone(), root)
+	}
+	keys := std.SortedKeys(t.contents)
+	contentMu.Lock()
+	defer contentMu.Unlock()
+	for _, key := range keys {
+		grammarRefs := maps.Clone(nested)
+		grammarRefs["main"] = renameRoot(t.contents[key].Clone(), root)
+		contentGrammars[key] = ast.NewGrammar(ast.RefLookup(grammarRefs))
+	}
+	t.contents = nil
+	return keys
+}
+
+// bindContents unregisters the nested grammars of the keys once the owner, which contains their content functions, is garbage collected.
+// The content functions look up their nested grammars whenever they are instantiated, so the owner has to be kept by everything that instantiates them,
+// which is why the matchers keep their grammars.
+func bindContents[T any](owner *T, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	runtime.AddCleanup(owner, unregisterContents, keys)
+}
+
+func unregisterContents(keys []string) {
+	contentMu.Lock()
+	defer contentMu.Unlock()
+	for _, key := range keys {
+		delete(contentGrammars, key)
+	}
+}
+
+// registeredContents returns the number of registered nested grammars.
+func registeredContents() int {
+	contentMu.Lock()
+	defer contentMu.Unlock()
+	return len(contentGrammars)
+}
+
+// renameRoot renames the references to main and root to contentRoot.
+func renameRoot(p *ast.Pattern, root string) *ast.Pattern {
+	p.Walk(renameRef{from: "main", to: contentRoot})
+	if root != "main" {
+		p.Walk(renameRef{from: root, to: contentRoot})
+	}
+	return p
+}
+
+func lookupContentGrammar(key string) (*ast.Grammar, error) {
+	contentMu.Lock()
+	defer contentMu.Unlock()
+	g, ok := contentGrammars[key]
+	if !ok {
+		return nil, fmt.Errorf("content schema %q is not registered", key)
+	}
+	return g, nil
+}
+
+var errContentVar = errors.New("content requires constant expressions as its first three parameters, but it has a variable parameter")
+
+// Content returns a new content function, which decodes the input string, checks its media type and validates it against the content schema.
+// The contentSchema parameter is the key of the nested grammar of the content schema, which is registered during translation.
+// It is registered in the translate package, instead of the funcs package, since it validates the content with a nested grammar.
+func Content(encoding funcs.ConstString, mediaType funcs.ConstString, contentSchema funcs.ConstString, input funcs.String) (funcs.Bool, error) {
+	if encoding.HasVariable() || mediaType.HasVariable() || contentSchema.HasVariable() {
+		return nil, errContentVar
+	}
+	enc, err := encoding.Eval()
+	if err != nil {
+		return nil, err
+	}
+	mt, err := mediaType.Eval()
+	if err != nil {
+		return nil, err
+	}
+	key, err := contentSchema.Eval()
+	if err != nil {
+		return nil, err
+	}
+	var g *ast.Grammar
+	if len(key) > 0 {
+		g, err = lookupContentGrammar(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return funcs.TrimBool(&content{
+		encoding:      enc,
+		mediaType:     mt,
+		contentSchema: key,
+		grammar:       g,
+		S:             input,
+		hash:          funcs.Hash("content", encoding, mediaType, contentSchema, input),
+		hasVariable:   input.HasVariable(),
+	}), nil
+}
+
+type content struct {
+	encoding      string
+	mediaType     string
+	contentSchema string
+	grammar       *ast.Grammar
+	S             funcs.String
+	hash          uint64
+	hasVariable   bool
+}
+
+func (this *content) HasVariable() bool {
+	return this.hasVariable
+}
+
+func (this *content) ToExpr() *ast.Expr {
+	return ast.NewFunction("content", ast.NewStringConst(this.encoding), ast.NewStringConst(this.mediaType), ast.NewStringConst(this.contentSchema), this.S.ToExpr())
+}
+
+func (this *content) Eval() (bool, error) {
+	s, err := this.S.Eval()
+	if err != nil {
+		// content only applies to strings
+		return true, nil
+	}
+	data, err := contentfuncs.DecodeContent(this.encoding, s)
+	if err != nil {
+		// unknown encodings cannot be validated, so they are only annotations.
+		return errors.Is(err, contentfuncs.ErrUnknownContentEncoding), nil
+	}
+	if !contentfuncs.IsJSONMediaType(this.mediaType) {
+		// only JSON media types are validated.
+		return true, nil
+	}
+	var v any
+	if err := std.UnmarshalJSON(data, &v); err != nil {
+		return false, nil
+	}
+	if this.grammar == nil {
+		return true, nil
+	}
+	p := json.NewJSONSchemaParser()
+	p.Init(data)
+	return intern.Interpret(this.grammar, true, p)
+}
+
+func (this *content) Compare(that funcs.Comparable) int {
+	if this.Hash() != that.Hash() {
+		if this.Hash() < that.Hash() {
+			return -1
+		}
+		return 1
+	}
+	if other, ok := that.(*content); ok {
+		if c := strings.Compare(this.encoding, other.encoding); c != 0 {
+			return c
+		}
+		if c := strings.Compare(this.mediaType, other.mediaType); c != 0 {
+			return c
+		}
+		if c := strings.Compare(this.contentSchema, other.contentSchema); c != 0 {
+			return c
+		}
+		return this.S.Compare(other.S)
+	}
+	return this.ToExpr().Compare(that.ToExpr())
+}
+
+func (this *content) Hash() uint64 {
+	return this.hash
+}
+
+func init() {
+	funcs.Register("content", Content)
+}
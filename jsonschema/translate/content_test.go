@@ -0,0 +1,54 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+func TestContentGrammarsAreReleased(t *testing.T) {
+	before := registeredContents()
+	translateMany := func() {
+		for range 100 {
+			s, err := schema.ParseSchema([]byte(`{"properties": {"a": {"contentMediaType": "application/json", "contentSchema": {"type": "integer"}}}}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.SetDefaultVersion(schema.VersionDraft2020)
+			if _, err := Translate(s, WithContentAssertion()); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := NewExplainerFromSchema(s, WithContentAssertion()); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	translateMany()
+	if registeredContents() == before {
+		t.Fatalf("expected the nested grammars to be registered while their grammars are reachable")
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for registeredContents() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the nested grammars to be released, but %d of them are still registered", registeredContents()-before)
+		}
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	encjson "encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+)
+
+// ApplyDefaults inserts the defaults of absent properties into the value, which is decoded by std.UnmarshalJSON.
+// Defaults are found through properties, references, allOf and the branch of if that matches, which is then if the value matches if, otherwise else.
+// The default of a property can also be the default of the schema that it refers to or of one of its allOf subschemas.
+// If several schemas have a default for the same property, then the first one wins, where properties comes before references, allOf and if.
+// An inserted default is a copy, which again gets the defaults of its own absent properties,
+// except for the properties that already got the same default higher up, so that recursive defaults stop.
+// A default of null is also inserted.
+func (e *Explainer) ApplyDefaults(value any) error {
+	sc := explainScope{base: e.t.rootId}
+	return e.applyDefaults(sc, e.root, value, nil, make(map[*schema.Schema]bool))
+}
+
+// applyDefaults inserts the defaults of the absent properties of the value and its parts,
+// where defaulted are the property schemas whose defaults were inserted higher up in the value.
+// visited contains the schemas that already applied to this value, which stops references and allOf that loop without consuming any of the value.
+func (e *Explainer) applyDefaults(sc explainScope, s *schema.Schema, value any, defaulted map[*schema.Schema]bool, visited map[*schema.Schema]bool) error {
+	if s.Bool != nil || visited[s] {
+		return nil
+	}
+	visited[s] = true
+	sc = e.enterId(sc, s)
+	keywords := keywordsOf(s)
+	if slices.Contains(keywords, "properties") {
+		obj, _ := value.(map[string]any)
+		props := s.GetProperties()
+		inserted := make(map[*schema.Schema]bool)
+		for _, name := range std.SortedKeys(props) {
+			if _, ok := obj[name]; ok || obj == nil || defaulted[props[name]] {
+				continue
+			}
+			d, ok, err := e.defaultOf(sc.keyword("properties", name), props[name], make(map[*schema.Schema]bool))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if obj[name], err = copyValue(d); err != nil {
+				return err
+			}
+			inserted[props[name]] = true
+		}
+		err := e.applySubschemas(sc.keyword("properties"), s, "properties", value, func(sc explainScope, s *schema.Schema, value any) error {
+			if !inserted[s] {
+				return e.applyDefaults(sc, s, value, defaulted, make(map[*schema.Schema]bool))
+			}
+			d := maps.Clone(defaulted)
+			if d == nil {
+				d = make(map[*schema.Schema]bool)
+			}
+			d[s] = true
+			return e.applyDefaults(sc, s, value, d, make(map[*schema.Schema]bool))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	apply := func(sc explainScope, s *schema.Schema, value any) error {
+		return e.applyDefaults(sc, s, value, defaulted, visited)
+	}
+	for _, keyword := range keywords {
+		switch keyword {
+		case "$ref", "$dynamicRef", "$recursiveRef", "allOf", "extends":
+			if err := e.applySubschemas(sc.keyword(keyword), s, keyword, value, apply); err != nil {
+				return err
+			}
+		}
+	}
+	if s.If == nil || s.GetVersion() < schema.VersionDraft7 || (len(s.Ref) > 0 && s.GetVersion() <= schema.VersionDraft7) {
+		return nil
+	}
+	matched, err := e.match(sc.parentId, sc.bindings, s.If, value)
+	if err != nil {
+		return err
+	}
+	if matched && s.Then != nil {
+		return e.applyDefaults(sc.keyword("then"), s.Then, value, defaulted, visited)
+	}
+	if !matched && s.Else != nil {
+		return e.applyDefaults(sc.keyword("else"), s.Else, value, defaulted, visited)
+	}
+	return nil
+}
+
+// defaultOf returns the default of the schema, the schema that it refers to or one of its allOf subschemas and whether a default was found.
+// visited contains the schemas that were already searched, which stops references and allOf that loop.
+func (e *Explainer) defaultOf(sc explainScope, s *schema.Schema, visited map[*schema.Schema]bool) (any, bool, error) {
+	if s.Bool != nil || visited[s] {
+		return nil, false, nil
+	}
+	visited[s] = true
+	// before draft version 7 ref silently ignores siblings
+	refOnly := len(s.Ref) > 0 && s.GetVersion() <= schema.VersionDraft7
+	if s.HasDefault && !refOnly {
+		return s.Default, true, nil
+	}
+	sc = e.enterId(sc, s)
+	if len(s.Ref) > 0 {
+		sc, sch, err := e.followRef(sc.keyword("$ref"), s, "$ref")
+		if err != nil {
+			return nil, false, err
+		}
+		d, ok, err := e.defaultOf(sc, sch, visited)
+		if err != nil || ok || refOnly {
+			return d, ok, err
+		}
+	}
+	for i, sch := range slices.Concat(s.AllOf, extendsOf(s)) {
+		d, ok, err := e.defaultOf(sc.keyword("allOf", strconv.Itoa(i)), sch, visited)
+		if err != nil || ok {
+			return d, ok, err
+		}
+	}
+	return nil, false, nil
+}
+
+// copyValue returns a deep copy of the value, so that inserting into the value does not change the schema.
+func copyValue(value any) (any, error) {
+	data, err := encjson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var c any
+	if err := std.UnmarshalJSON(data, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -15,286 +15,588 @@
 package translate
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
 	"strconv"
 	"strings"
 
+	"github.com/katydid/validator-go-jsonschema/jsonschema/loader"
 	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
 	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
 	"github.com/katydid/validator-go/validator/ast"
 )
 
-func findDefinitions(s *schema.Schema) (map[string]*schema.Schema, error) {
-	defs := make(map[string]*schema.Schema)
-	err := findSchemaDefinitions(s, "", "", s, defs)
-	if err != nil {
-		return nil, err
+// definitionFinder finds the schemas that references resolve to, including the schemas in the documents that are loaded for references to other documents.
+type definitionFinder struct {
+	loader    loader.Loader
+	resources *Resources
+	// uniqueItems is whether uniqueItems are validated, otherwise they are removed from the meta-schemas.
+	uniqueItems bool
+	// dialects maps the $schema URIs of custom dialects to the registered or resolved dialects.
+	dialects map[string]*schema.Dialect
+	// rootURI is the base URI of the root schema.
+	rootURI string
+	// ids maps the absolute URIs of schema resources and anchors to the locations of their schemas.
+	ids map[string]*location
+	// refs are all the references, which are resolved after all the schema resources and anchors are found.
+	refs []documentRef
+	// bases maps the definition names to the base URIs of their parents, which the definitions are translated with.
+	bases map[string]string
+	// documents maps the URIs of the loaded documents to their schemas.
+	documents map[string]*schema.Schema
+}
+
+// location is a schema and the base URI of its parent.
+type location struct {
+	parentId string
+	schema   *schema.Schema
+}
+
+// documentRef is a reference, with the base URI and version of the schema that contains the reference.
+type documentRef struct {
+	base    string
+	ref     string
+	version schema.Version
+}
+
+func newDefinitionFinder(o *options) *definitionFinder {
+	f := &definitionFinder{
+		loader:      o.loader,
+		resources:   o.resources,
+		uniqueItems: o.uniqueItems != nil,
+		dialects:    make(map[string]*schema.Dialect),
+		ids:         make(map[string]*location),
+		bases:       make(map[string]string),
+		documents:   make(map[string]*schema.Schema),
 	}
-	return defs, nil
+	maps.Copy(f.dialects, o.dialects)
+	return f
 }
 
-func getId(parentId string, s *schema.Schema) string {
-	if len(s.Id) == 0 {
-		return parentId
+func (f *definitionFinder) findDefinitions(s *schema.Schema) (map[string]*schema.Schema, error) {
+	defs := make(map[string]*schema.Schema)
+	if err := f.resolveDialects(s); err != nil {
+		return nil, err
 	}
-	if len(parentId) == 0 {
-		return s.Id
+	if err := s.CheckDraft3Forms(); err != nil {
+		return nil, err
 	}
-	idPaths, err := parsePointer(s.Id)
-	if err != nil {
-		idPaths = []string{s.Id}
+	f.rootURI = getId("", s)
+	f.ids[f.rootURI] = &location{parentId: "", schema: s}
+	if err := f.findSchemaDefinitions("", s, defs); err != nil {
+		return nil, err
+	}
+	if err := f.loadDocuments(defs); err != nil {
+		return nil, err
+	}
+	if err := f.resolveRefs(defs); err != nil {
+		return nil, err
 	}
-	return prependParentId(parentId, idPaths)
+	return defs, nil
 }
 
-func findSchemaDefinitions(root *schema.Schema, parentId string, prefix string, s *schema.Schema, res map[string]*schema.Schema) error {
-	for _, name := range std.SortedKeys(s.Definitions) {
-		sch := s.Definitions[name]
-		defname, err := definitionToDefName(prefix, getId(parentId, s), name, s.Definitions[name].Id, sch.Anchor)
+// loadDocuments loads the documents of the references that refer to a schema resource that was not found.
+// Loaded documents can again contain references to other documents.
+func (f *definitionFinder) loadDocuments(res map[string]*schema.Schema) error {
+	for i := 0; i < len(f.refs); i++ {
+		ref := f.refs[i]
+		uri, err := resolveURI(ref.base, ref.ref)
 		if err != nil {
 			return err
 		}
-		if _, ok := res[defname]; ok {
-			return fmt.Errorf("duplicate definition name: %s", defname)
+		docURI, _ := splitFragment(uri)
+		if _, ok := f.ids[docURI]; ok || !isAbsoluteURI(docURI) {
+			continue
 		}
-		res[defname] = sch
-	}
-	for _, name := range std.SortedKeys(s.Definitions) {
-		sch := s.Definitions[name]
-		newprefix := definitionToPrefix(prefix, name, getId(parentId, s))
-		if err := findSchemaDefinitions(root, getId(parentId, s), newprefix, sch, res); err != nil {
+		doc, err := f.loadDocument(docURI, ref)
+		if err != nil {
 			return err
 		}
-	}
-	if sch := s.Array.AdditionalItems.GetSchema(); sch != nil {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/additionalItems", sch, res); err != nil {
-			return err
+		if doc == nil {
+			continue
 		}
-	}
-	if sch := s.Array.GetItems().GetObject(); sch != nil {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/items", sch, res); err != nil {
+		if err := f.resolveDialects(doc); err != nil {
 			return err
 		}
-	}
-	for i, sch := range s.Array.GetItems().GetArray() {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/items/"+strconv.Itoa(i), sch, res); err != nil {
+		if err := doc.CheckDraft3Forms(); err != nil {
+			return fmt.Errorf("could not parse %s: %w", docURI, err)
+		}
+		f.documents[docURI] = doc
+		// the retrieval URI is the base URI of the document, unless the document has a different id.
+		f.ids[docURI] = &location{parentId: docURI, schema: doc}
+		if err := f.findSchemaDefinitions(docURI, doc, res); err != nil {
 			return err
 		}
 	}
-	if sch := s.Object.AdditionalProperties.GetSchema(); sch != nil {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/additionalProperties", sch, res); err != nil {
-			return err
+	return nil
+}
+
+// loadDocument returns the added resource or the embedded meta-schema for the URI, or otherwise loads and parses the document.
+// It returns nil if a relative reference cannot be loaded, since the reference is then reported as not found when it is translated.
+func (f *definitionFinder) loadDocument(uri string, ref documentRef) (*schema.Schema, error) {
+	if doc, ok := f.resources.Get(uri); ok {
+		return doc, nil
+	}
+	if schema.IsMetaSchema(uri) {
+		doc, err := schema.ParseMetaSchema(uri)
+		if err != nil {
+			return nil, err
+		}
+		if !f.uniqueItems {
+			// uniqueItems are not supported without WithUniqueItems, so they are removed from the meta-schemas.
+			doc.Walk(func(s *schema.Schema) {
+				s.UniqueItems = false
+			})
 		}
+		return doc, nil
 	}
-	for _, sch := range s.Object.GetProperties() {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/properties", sch, res); err != nil {
-			return err
+	if f.loader == nil {
+		return nil, nil
+	}
+	data, err := f.loader.Load(uri)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !isAbsoluteURI(ref.ref) {
+			return nil, nil
 		}
+		return nil, fmt.Errorf("could not load %s: %w", uri, err)
 	}
-	for _, sch := range s.Object.PatternProperties {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/patternProperties", sch, res); err != nil {
-			return err
+	doc, err := schema.ParseSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", uri, err)
+	}
+	doc.SetDefaultVersion(ref.version)
+	return doc, nil
+}
+
+// resolveDialects resolves the custom dialects of the $schema URIs that are not official meta-schemas and removes the keywords of the vocabularies that are not enabled.
+// A custom dialect is either registered or described by its meta-schema, which is loaded.
+// If the meta-schema cannot be loaded, the schema is interpreted as the default version.
+func (f *definitionFinder) resolveDialects(s *schema.Schema) error {
+	var err error
+	s.Walk(func(sch *schema.Schema) {
+		if err != nil {
+			return
 		}
+		if _, ok := schema.LookupDialect(sch.Schema); ok {
+			return
+		}
+		var d *schema.Dialect
+		d, err = f.resolveDialect(sch.Schema, sch.GetVersion())
+		if d != nil {
+			sch.Dialect = d
+		}
+	})
+	if err != nil {
+		return err
 	}
-	if s.Operators.Dependencies != nil {
-		for name, dep := range *s.Operators.Dependencies {
-			if sch := dep.Schema; sch != nil {
-				if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/dependencies/"+name, sch, res); err != nil {
-					return err
-				}
-			}
+	s.ApplyVocabularies()
+	return nil
+}
+
+// resolveDialect returns the registered dialect or the dialect that is described by the loaded meta-schema.
+// It returns nil if the meta-schema does not exist.
+func (f *definitionFinder) resolveDialect(uri string, defaultVersion schema.Version) (*schema.Dialect, error) {
+	uri = strings.TrimSuffix(uri, "#")
+	if d, ok := f.dialects[uri]; ok {
+		return d, nil
+	}
+	d, err := f.loadDialect(uri, defaultVersion)
+	if err != nil {
+		return nil, err
+	}
+	f.dialects[uri] = d
+	return d, nil
+}
+
+func (f *definitionFinder) loadDialect(uri string, defaultVersion schema.Version) (*schema.Dialect, error) {
+	if !isAbsoluteURI(uri) {
+		return nil, nil
+	}
+	metaSchema, err := f.loadDocument(uri, documentRef{ref: uri, version: defaultVersion})
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
 		}
+		return nil, err
 	}
-	for _, sch := range s.AllOf {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/allOf", sch, res); err != nil {
+	if metaSchema == nil {
+		return nil, nil
+	}
+	return schema.NewDialect(metaSchema)
+}
+
+// resolveRefs adds the schemas that the references resolve to as definitions.
+// References that cannot be resolved are reported when they are translated.
+func (f *definitionFinder) resolveRefs(res map[string]*schema.Schema) error {
+	for _, ref := range f.refs {
+		uri, err := resolveURI(ref.base, ref.ref)
+		if err != nil {
 			return err
 		}
+		if len(uri) == 0 {
+			// the root schema without an id is the main definition.
+			continue
+		}
+		defName := uriToDefName(uri)
+		if _, ok := res[defName]; ok {
+			continue
+		}
+		if loc := f.lookup(uri); loc != nil {
+			if err := f.addDefinition(res, defName, loc); err != nil {
+				return err
+			}
+		}
 	}
-	for _, sch := range s.AnyOf {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/anyOf", sch, res); err != nil {
+	return nil
+}
+
+// lookup returns the location of the schema that the URI identifies, which is either a schema resource, an anchor or a json pointer into a schema resource.
+func (f *definitionFinder) lookup(uri string) *location {
+	if loc, ok := f.ids[uri]; ok {
+		return loc
+	}
+	docURI, fragment := splitFragment(uri)
+	if !strings.HasPrefix(fragment, "/") {
+		return nil
+	}
+	resource, ok := f.ids[docURI]
+	if !ok {
+		return nil
+	}
+	pointer, err := parsePointer(fragment)
+	if err != nil {
+		return nil
+	}
+	return findSchema(getId(resource.parentId, resource.schema), pointer, resource.schema)
+}
+
+func (f *definitionFinder) addId(uri string, loc *location) error {
+	if other, ok := f.ids[uri]; ok && other.schema != loc.schema {
+		return fmt.Errorf("duplicate id: %s", uri)
+	}
+	f.ids[uri] = loc
+	return nil
+}
+
+func (f *definitionFinder) addDefinition(res map[string]*schema.Schema, defName string, loc *location) error {
+	if other, ok := res[defName]; ok && other != loc.schema {
+		return fmt.Errorf("duplicate definition name: %s", defName)
+	}
+	res[defName] = loc.schema
+	f.bases[defName] = loc.parentId
+	return nil
+}
+
+// findSchemaDefinitions walks all the subschemas to find the schema resources, anchors and references, while it keeps track of the base URI.
+func (f *definitionFinder) findSchemaDefinitions(parentId string, s *schema.Schema, res map[string]*schema.Schema) error {
+	if s.Bool != nil {
+		return nil
+	}
+	id := getId(parentId, s)
+	loc := &location{parentId: parentId, schema: s}
+	if id != parentId {
+		if err := f.addId(id, loc); err != nil {
 			return err
 		}
 	}
-	for _, sch := range s.OneOf {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/oneOf", sch, res); err != nil {
+	if anchor := getAnchor(s); len(anchor) > 0 {
+		if err := f.addId(id+"#"+anchor, loc); err != nil {
 			return err
 		}
 	}
-	if sch := s.Not; sch != nil {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/not", sch, res); err != nil {
+	if len(s.DynamicAnchor) > 0 && s.GetVersion() >= schema.VersionDraft2020 {
+		// a $dynamicAnchor is also an anchor, but it is always defined, since a $dynamicRef in another schema resource can resolve to it.
+		defName := dynamicAnchorToDefName(id, s.DynamicAnchor)
+		if err := f.addId(defName, loc); err != nil {
 			return err
 		}
-	}
-	if sch := s.If; sch != nil {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/if", sch, res); err != nil {
+		if err := f.addDefinition(res, defName, loc); err != nil {
 			return err
 		}
 	}
-	if sch := s.Then; sch != nil {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/then", sch, res); err != nil {
+	if s.RecursiveAnchor && len(s.GetId()) > 0 && s.GetVersion() == schema.VersionDraft2019 {
+		// the root of a schema resource that a $recursiveRef can resolve to.
+		if err := f.addDefinition(res, id, loc); err != nil {
 			return err
 		}
 	}
-	if sch := s.Else; sch != nil {
-		if err := findSchemaDefinitions(root, getId(parentId, s), prefix+"/else", sch, res); err != nil {
+	if len(s.Ref) > 0 {
+		f.addRef(id, s.Ref, s)
+	}
+	if len(s.DynamicRef) > 0 && s.GetVersion() >= schema.VersionDraft2020 {
+		f.addRef(id, s.DynamicRef, s)
+	}
+	if len(s.RecursiveRef) > 0 && s.GetVersion() == schema.VersionDraft2019 {
+		f.addRef(id, s.RecursiveRef, s)
+	}
+	for _, sch := range subschemas(s) {
+		if err := f.findSchemaDefinitions(id, sch, res); err != nil {
 			return err
 		}
 	}
-	if len(s.Ref) > 0 {
-		if s.Ref == "#" {
-			// main reference is already added, so nothing to do here.
-		} else if strings.HasPrefix(s.Ref, "#/definitions/") {
-			// other definitions are already added, so nothing to do there.
-		} else if strings.HasPrefix(s.Ref, "#/") {
-			pointer, err := parsePointer(s.Ref)
-			if err != nil {
-				return err
-			}
-			sch := findSchema(pointer, root)
-			if sch == nil {
-				return fmt.Errorf("could not find schema for %s", s.Ref)
-			}
-			defName, err := refToDefName(getId(parentId, s), s.Ref)
-			if err != nil {
-				return err
-			}
-			res[defName] = sch
-		} else if strings.HasPrefix(s.Ref, "http") {
-			defName, err := refToDefName(getId(parentId, s), s.Ref)
-			if err != nil {
-				return err
-			}
-			switch defName {
-			case "http://json-schema.org/draft-04/schema":
-				if _, ok := res[defName]; ok {
-					return nil
-				}
-				// handle meta schema validation with unique items
-				s, err := schema.ParseSchema([]byte(schema.SchemaDraft4ExlcudeUniqueItems))
-				if err != nil {
-					return err
-				}
-				s.Id = ""
-				s.SetDefaultVersion(schema.VersionDraft4)
-				res[defName] = s
-				if err := findSchemaDefinitions(s, "", "", s, res); err != nil {
-					return err
-				}
+	return nil
+}
+
+func (f *definitionFinder) addRef(base string, ref string, s *schema.Schema) {
+	f.refs = append(f.refs, documentRef{base: base, ref: ref, version: s.GetVersion()})
+}
+
+// subschemas returns the subschemas of all the keywords that apply subschemas in a deterministic order.
+func subschemas(s *schema.Schema) []*schema.Schema {
+	schs := []*schema.Schema{}
+	for _, name := range std.SortedKeys(s.Definitions) {
+		schs = append(schs, s.Definitions[name])
+	}
+	for _, name := range std.SortedKeys(s.Defs) {
+		schs = append(schs, s.Defs[name])
+	}
+	if sch := s.Array.GetAdditionalItems().GetSchema(); sch != nil {
+		schs = append(schs, sch)
+	}
+	if sch := s.Array.GetItems().GetObject(); sch != nil {
+		schs = append(schs, sch)
+	}
+	schs = append(schs, s.Array.GetItems().GetArray()...)
+	schs = append(schs, s.Array.PrefixItems...)
+	if sch := s.Array.Contains; sch != nil {
+		schs = append(schs, sch)
+	}
+	if sch := s.Array.UnevaluatedItems; sch != nil {
+		schs = append(schs, sch)
+	}
+	if sch := s.String.ContentSchema; sch != nil {
+		schs = append(schs, sch)
+	}
+	if sch := s.Object.GetAdditionalProperties().GetSchema(); sch != nil {
+		schs = append(schs, sch)
+	}
+	properties := s.Object.GetProperties()
+	for _, name := range std.SortedKeys(properties) {
+		schs = append(schs, properties[name])
+	}
+	patternProperties := s.Object.GetPatternProperties()
+	for _, name := range std.SortedKeys(patternProperties) {
+		schs = append(schs, patternProperties[name])
+	}
+	if sch := s.Object.PropertyNames; sch != nil {
+		schs = append(schs, sch)
+	}
+	if sch := s.Object.UnevaluatedProperties; sch != nil {
+		schs = append(schs, sch)
+	}
+	if deps := s.Operators.Dependencies; deps != nil {
+		for _, name := range std.SortedKeys(*deps) {
+			if sch := (*deps)[name].Schema; sch != nil {
+				schs = append(schs, sch)
 			}
-		} else if strings.HasPrefix(s.Ref, "file") {
-			return fmt.Errorf("file ref not supported")
 		}
 	}
+	for _, name := range std.SortedKeys(s.Operators.DependentSchemas) {
+		schs = append(schs, s.Operators.DependentSchemas[name])
+	}
+	schs = append(schs, s.Operators.AllOf...)
+	schs = append(schs, s.Operators.AnyOf...)
+	schs = append(schs, s.Operators.OneOf...)
+	if sch := s.Operators.Not; sch != nil {
+		schs = append(schs, sch)
+	}
+	if sch := s.Operators.If; sch != nil {
+		schs = append(schs, sch)
+	}
+	if sch := s.Operators.Then; sch != nil {
+		schs = append(schs, sch)
+	}
+	if sch := s.Operators.Else; sch != nil {
+		schs = append(schs, sch)
+	}
+	if sch := s.Draft3.Extends.GetObject(); sch != nil {
+		schs = append(schs, sch)
+	}
+	schs = append(schs, s.Draft3.Extends.GetArray()...)
+	if s.Draft3.Disallow != nil {
+		schs = append(schs, s.Draft3.Disallow.Schemas...)
+	}
+	if s.Draft3.TypeUnion != nil {
+		schs = append(schs, s.Draft3.TypeUnion.Schemas...)
+	}
+	return schs
+}
 
-	return nil
+// findSchema returns the location of the subschema that the json pointer refers to, where base is the base URI of s.
+func findSchema(base string, pointer []string, s *schema.Schema) *location {
+	child, rest := childSchema(pointer, s)
+	if child == nil {
+		return nil
+	}
+	if len(rest) == 0 {
+		return &location{parentId: base, schema: child}
+	}
+	return findSchema(getId(base, child), rest, child)
 }
 
-func findSchema(pointer []string, s *schema.Schema) *schema.Schema {
+// childSchema returns the subschema that the first reference tokens of the json pointer refer to and the rest of the json pointer.
+// Every keyword that applies a subschema can be referenced, regardless of the version of the schema.
+func childSchema(pointer []string, s *schema.Schema) (*schema.Schema, []string) {
 	if len(pointer) == 0 {
-		return nil
+		return nil, nil
 	}
-	name := pointer[0]
+	name, rest := pointer[0], pointer[1:]
 	switch name {
-	case "properties":
-		if len(pointer) < 2 {
-			return nil
-		}
-		sch, ok := s.GetProperties()[pointer[1]]
-		if !ok {
-			return nil
-		}
-		if len(pointer) > 2 {
-			return findSchema(pointer[2:], sch)
-		}
-		return sch
 	case "definitions":
-		if s.Definitions == nil {
-			return nil
-		}
-		sch, ok := s.Definitions[pointer[1]]
+		return keySchema(s.Definitions, rest)
+	case "$defs":
+		return keySchema(s.Defs, rest)
+	case "additionalItems":
+		return additionalSchema(s.AdditionalItems), rest
+	case "items":
+		if sch := s.Items.GetObject(); sch != nil {
+			return sch, rest
+		}
+		return indexSchema(s.Items.GetArray(), rest)
+	case "prefixItems":
+		return indexSchema(s.PrefixItems, rest)
+	case "contains":
+		return s.Contains, rest
+	case "unevaluatedItems":
+		return s.UnevaluatedItems, rest
+	case "contentSchema":
+		return s.ContentSchema, rest
+	case "additionalProperties":
+		return additionalSchema(s.AdditionalProperties), rest
+	case "properties":
+		return keySchema(s.GetProperties(), rest)
+	case "patternProperties":
+		return keySchema(s.PatternProperties, rest)
+	case "propertyNames":
+		return s.PropertyNames, rest
+	case "unevaluatedProperties":
+		return s.UnevaluatedProperties, rest
+	case "dependencies":
+		if s.Dependencies == nil || len(rest) == 0 {
+			return nil, nil
+		}
+		dep, ok := (*s.Dependencies)[rest[0]]
 		if !ok {
-			return nil
-		}
-		if len(pointer) > 2 {
-			return findSchema(pointer[2:], sch)
-		}
-		return sch
+			return nil, nil
+		}
+		return dep.Schema, rest[1:]
+	case "dependentSchemas":
+		return keySchema(s.DependentSchemas, rest)
+	case "allOf":
+		return indexSchema(s.AllOf, rest)
+	case "anyOf":
+		return indexSchema(s.AnyOf, rest)
+	case "oneOf":
+		return indexSchema(s.OneOf, rest)
+	case "not":
+		return s.Not, rest
 	case "if":
-		sch := s.If
-		if sch == nil {
-			return nil
-		}
-		if len(pointer) > 1 {
-			return findSchema(pointer[1:], sch)
-		}
-		return sch
-	case "else":
-		sch := s.Else
-		if sch == nil {
-			return nil
-		}
-		if len(pointer) > 1 {
-			return findSchema(pointer[1:], sch)
-		}
-		return sch
+		return s.If, rest
 	case "then":
-		sch := s.Then
-		if sch == nil {
-			return nil
-		}
-		if len(pointer) > 1 {
-			return findSchema(pointer[1:], sch)
-		}
-		return sch
-	case "items":
-		if sch := s.Items.GetObject(); sch != nil {
-			if len(pointer) == 1 {
-				return sch
-			}
-			return findSchema(pointer[1:], sch)
-		}
-		if len(pointer) < 2 {
-			return nil
-		}
-		idx, err := strconv.Atoi(pointer[1])
-		if err != nil {
-			return nil
-		}
-		if idx >= len(s.Items.GetArray()) {
-			return nil
-		}
-		sch := s.Items.GetArray()[idx]
-		if len(pointer) == 2 {
-			return sch
+		return s.Then, rest
+	case "else":
+		return s.Else, rest
+	case "extends":
+		if sch := s.Extends.GetObject(); sch != nil {
+			return sch, rest
 		}
-		return findSchema(pointer[2:], sch)
-	default:
+		return indexSchema(s.Extends.GetArray(), rest)
+	}
+	return nil, nil
+}
+
+// additionalSchema returns the schema of additionalItems or additionalProperties, where a boolean is returned as a boolean schema.
+func additionalSchema(a *schema.Additional) *schema.Schema {
+	if a == nil {
 		return nil
 	}
+	if a.Bool != nil {
+		return &schema.Schema{Bool: a.Bool}
+	}
+	return a.Schema
 }
 
-func translateDefinitions(s *schema.Schema) (map[string]*ast.Pattern, error) {
+func keySchema(schs map[string]*schema.Schema, pointer []string) (*schema.Schema, []string) {
+	if len(pointer) == 0 {
+		return nil, nil
+	}
+	return schs[pointer[0]], pointer[1:]
+}
+
+func indexSchema(schs []*schema.Schema, pointer []string) (*schema.Schema, []string) {
+	if len(pointer) == 0 {
+		return nil, nil
+	}
+	idx, err := strconv.Atoi(pointer[0])
+	if err != nil || idx < 0 || idx >= len(schs) {
+		return nil, nil
+	}
+	return schs[idx], pointer[1:]
+}
+
+// newDefinitions translates all the definitions and returns the translator, which can translate more patterns that refer to these definitions.
+func newDefinitions(s *schema.Schema, o *options) (*translator, map[string]*ast.Pattern, error) {
+	if o.resources != nil {
+		if err := o.resources.checkOptions(o); err != nil {
+			return nil, nil, err
+		}
+	}
 	refs := make(map[string]*ast.Pattern)
-	defs, err := findDefinitions(s)
+	f := newDefinitionFinder(o)
+	defs, err := f.findDefinitions(s)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if _, ok := defs["main"]; ok {
-		return nil, fmt.Errorf("main is a reserved definition name for katydid")
-	}
-	if len(s.Id) > 0 {
-		defs[s.Id] = s
+		return nil, nil, fmt.Errorf("main is a reserved definition name for katydid")
 	}
 	// katydid starts with the main pattern
 	defs["main"] = s
+	f.bases["main"] = ""
+	if o.uniqueItems != nil {
+		if err := o.uniqueItems.init(s, defs, f.bases); err != nil {
+			return nil, nil, err
+		}
+	}
+	t := newTranslator(s, defs, f.bases, f.documents, o)
 	names := std.SortedKeys(defs)
 	for _, name := range names {
-		p, err := translate(s.Id, defs[name])
+		if p, ok := t.cachedDefinition(name); ok {
+			refs[name] = p
+			continue
+		}
+		contents := len(t.contents)
+		p, err := t.translateDefinition(t.bases[name], name, t.defaultBindings(name))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if len(t.contents) == contents {
+			// definitions with content functions are not cached, since their nested grammars are owned by this translation.
+			t.cacheDefinition(name, p)
 		}
 		refs[name] = p
 	}
+	if err := t.translatePending(refs); err != nil {
+		return nil, nil, err
+	}
+	t.contentKeys = t.registerContents(refs, "main")
+	return t, refs, nil
+}
 
-	return refs, nil
+// translatePending translates the pending specializations into refs.
+// References in other dynamic scopes require specialized definitions, which can again require more specialized definitions.
+func (t *translator) translatePending(refs map[string]*ast.Pattern) error {
+	for len(t.pending) > 0 {
+		name := t.pending[0]
+		t.pending = t.pending[1:]
+		spec := t.specializations[name]
+		p, err := t.translateDefinition(t.bases[spec.defName], spec.defName, spec.bindings)
+		if err != nil {
+			return err
+		}
+		refs[name] = p
+	}
+	return nil
 }
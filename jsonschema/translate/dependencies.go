@@ -20,7 +20,7 @@ import (
 	"github.com/katydid/validator-go/validator/ast"
 )
 
-func translateDependencies(parentId string, deps *schema.Dependencies) (*ast.Pattern, error) {
+func (t *translator) translateDependencies(parentId string, deps *schema.Dependencies) (*ast.Pattern, error) {
 	d := *deps
 	dependentRequired := make(map[string][]string)
 	dependentSchemas := make(map[string]*schema.Schema)
@@ -35,7 +35,7 @@ func translateDependencies(parentId string, deps *schema.Dependencies) (*ast.Pat
 	if err != nil {
 		return nil, err
 	}
-	p2, err := translateDependentSchemas(parentId, dependentSchemas)
+	p2, err := t.translateDependentSchemas(parentId, dependentSchemas)
 	if err != nil {
 		return nil, err
 	}
@@ -59,11 +59,11 @@ func translateDependentRequired(deps map[string][]string) (*ast.Pattern, error)
 	return newAnd(res...), nil
 }
 
-func translateDependentSchemas(parentId string, deps map[string]*schema.Schema) (*ast.Pattern, error) {
+func (t *translator) translateDependentSchemas(parentId string, deps map[string]*schema.Schema) (*ast.Pattern, error) {
 	res := []*ast.Pattern{}
 	names := std.SortedKeys(deps)
 	for _, name := range names {
-		thenPat, err := translate(parentId, deps[name])
+		thenPat, err := t.translate(parentId, deps[name])
 		if err != nil {
 			return nil, err
 		}
@@ -76,3 +76,11 @@ func translateDependentSchemas(parentId string, deps map[string]*schema.Schema)
 	}
 	return newAnd(res...), nil
 }
+
+// dependentSchemasOf returns dependentSchemas, which is only a keyword since Draft 2019-09.
+func dependentSchemasOf(s *schema.Schema) map[string]*schema.Schema {
+	if s.GetVersion() < schema.VersionDraft2019 {
+		return nil
+	}
+	return s.DependentSchemas
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+	"github.com/katydid/validator-go/validator/ast"
+)
+
+// requiredOf returns the names of the required properties.
+// In Draft 3 a property is required if its own schema has "required": true, instead of being listed in the parent's required array.
+func requiredOf(s *schema.Schema) []string {
+	if s.GetVersion() != schema.VersionDraft3 {
+		return s.Required
+	}
+	var required []string
+	props := s.GetProperties()
+	for _, name := range std.SortedKeys(props) {
+		if props[name].RequiredProperty {
+			required = append(required, name)
+		}
+	}
+	return required
+}
+
+// extendsOf returns the schemas of extends, which is a schema or an array of schemas, but only in Draft 3.
+func extendsOf(s *schema.Schema) []*schema.Schema {
+	if s.GetVersion() != schema.VersionDraft3 {
+		return nil
+	}
+	if sch := s.Extends.GetObject(); sch != nil {
+		return []*schema.Schema{sch}
+	}
+	return s.Extends.GetArray()
+}
+
+// translateDraft3 translates the keywords that were removed after Draft 3 into the patterns of their later replacements.
+func (t *translator) translateDraft3(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+	var ps []*ast.Pattern
+	if s.DivisibleBy != nil {
+		// divisibleBy was renamed to multipleOf
+		p, err := translateNumeric(schema.Numeric{MultipleOf: s.DivisibleBy})
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, newOr(p, notNumberType()))
+	}
+	if extends := extendsOf(s); len(extends) > 0 {
+		// extends is the same as allOf
+		extendsPs, err := std.MapErr(extends, t.translateWithParentId(getId(parentId, s)))
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, newAnd(extendsPs...))
+	}
+	if s.Disallow != nil {
+		p, err := t.translateTypeUnion(getId(parentId, s), s.Disallow)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, ast.NewNot(p))
+	}
+	if s.TypeUnion != nil {
+		p, err := t.translateTypeUnion(getId(parentId, s), s.TypeUnion)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	return newAnd(ps...), nil
+}
+
+// translateTypeUnion translates a Draft 3 type union, which matches a value if any of its simple types or schemas match.
+func (t *translator) translateTypeUnion(parentId string, u *schema.TypeUnion) (*ast.Pattern, error) {
+	if u.Any {
+		return ast.NewZAny(), nil
+	}
+	var ps []*ast.Pattern
+	for _, typ := range u.Types {
+		ps = append(ps, simpleTypePattern(typ))
+	}
+	schemas, err := std.MapErr(u.Schemas, t.translateWithParentId(parentId))
+	if err != nil {
+		return nil, err
+	}
+	return newOr(append(ps, schemas...)...), nil
+}
+
+func simpleTypePattern(typ schema.SimpleType) *ast.Pattern {
+	switch typ {
+	case schema.TypeNull:
+		return nullType()
+	case schema.TypeBoolean:
+		return boolType()
+	case schema.TypeInteger:
+		return integerType()
+	case schema.TypeNumber:
+		return numberType()
+	case schema.TypeString:
+		return stringType()
+	case schema.TypeArray:
+		return arrayType()
+	case schema.TypeObject:
+		return objectType()
+	}
+	return ast.NewNot(ast.NewZAny())
+}
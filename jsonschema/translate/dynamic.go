@@ -0,0 +1,238 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+	"github.com/katydid/validator-go/validator/ast"
+)
+
+// A $dynamicRef resolves to a different schema depending on the dynamic scope,
+// which is the list of schema resources that were entered to reach the $dynamicRef, for example:
+//
+//	{
+//		"$id": "https://example.com/root",
+//		"$ref": "list",
+//		"$defs": {
+//			"foo": {"$dynamicAnchor": "items", "type": "string"},
+//			"list": {
+//				"$id": "list",
+//				"items": {"$dynamicRef": "#items"},
+//				"$defs": {"items": {"$dynamicAnchor": "items"}}
+//			}
+//		}
+//	}
+//
+// The outermost schema resource that declares the $dynamicAnchor wins, which means that the items of list are strings when list is entered from the root,
+// but are not constrained when list is used on its own.
+// The grammar has no dynamic scope, so we specialize each definition per dynamic scope.
+// A dynamic scope is represented by bindings, which map the anchor names to the definition name of the outermost $dynamicAnchor.
+// Only the anchors that are referenced by a $dynamicRef are bound, which keeps the number of specializations small.
+// The $recursiveRef and $recursiveAnchor keywords of Draft 2019-09 are resolved in the same way,
+// where $recursiveAnchor is bound to the recursiveAnchor name, which cannot clash with an anchor name.
+
+// recursiveAnchor is the binding name of $recursiveAnchor.
+const recursiveAnchor = "#"
+
+// specialization is a definition that is translated in a specific dynamic scope.
+type specialization struct {
+	defName  string
+	bindings map[string]string
+}
+
+// findDynamicScopes indexes the $dynamicAnchors of every schema resource and the anchors that are referenced by a $dynamicRef.
+func (t *translator) findDynamicScopes(root *schema.Schema) {
+	t.dynamicAnchors = make(map[string]bool)
+	visit := func(s *schema.Schema) {
+		if len(s.DynamicRef) > 0 && s.GetVersion() >= schema.VersionDraft2020 {
+			if anchor := refToAnchor(s.DynamicRef); len(anchor) > 0 {
+				t.dynamicAnchors[anchor] = true
+			}
+		}
+		if len(s.RecursiveRef) > 0 && s.GetVersion() == schema.VersionDraft2019 {
+			t.dynamicAnchors[recursiveAnchor] = true
+		}
+	}
+	root.Walk(visit)
+	for _, uri := range std.SortedKeys(t.documents) {
+		t.documents[uri].Walk(visit)
+	}
+	t.resources = make(map[string]map[string]string)
+	t.resources[t.rootId] = make(map[string]string)
+	if len(t.dynamicAnchors) == 0 {
+		return
+	}
+	for name, s := range t.defs {
+		if len(s.GetId()) > 0 && name != "main" {
+			id := getId(t.bases[name], s)
+			if _, ok := t.resources[id]; !ok {
+				t.resources[id] = make(map[string]string)
+			}
+		}
+	}
+	for name, s := range t.defs {
+		if s.RecursiveAnchor && s.GetVersion() == schema.VersionDraft2019 {
+			id := getId(t.bases[name], s)
+			if id == t.rootId {
+				t.resources[t.rootId][recursiveAnchor] = "main"
+			} else if _, ok := t.resources[id]; ok && t.defs[id] == s {
+				t.resources[id][recursiveAnchor] = id
+			}
+		}
+		if len(s.DynamicAnchor) == 0 || !strings.HasSuffix(name, "#"+s.DynamicAnchor) {
+			continue
+		}
+		resourceId := strings.TrimSuffix(name, "#"+s.DynamicAnchor)
+		if _, ok := t.resources[resourceId]; !ok {
+			t.resources[resourceId] = make(map[string]string)
+		}
+		t.resources[resourceId][s.DynamicAnchor] = name
+	}
+}
+
+// resourceOf returns the id of the schema resource that contains the definition.
+// This is the longest resource id that is the definition name or the definition name without its fragment, otherwise it is the root schema resource.
+func (t *translator) resourceOf(defName string) string {
+	if defName == "main" {
+		return t.rootId
+	}
+	res := t.rootId
+	for id := range t.resources {
+		if len(id) <= len(res) {
+			continue
+		}
+		if defName == id || strings.HasPrefix(defName, id+"#") {
+			res = id
+		}
+	}
+	return res
+}
+
+// enter returns the bindings after entering the schema resource, where anchors that are already bound stay bound to the outermost schema resource.
+func (t *translator) enter(resourceId string) map[string]string {
+	anchors := t.resources[resourceId]
+	if len(anchors) == 0 {
+		return t.bindings
+	}
+	bindings := maps.Clone(t.bindings)
+	if bindings == nil {
+		bindings = make(map[string]string)
+	}
+	for anchor, defName := range anchors {
+		if !t.dynamicAnchors[anchor] {
+			continue
+		}
+		if _, ok := bindings[anchor]; !ok {
+			bindings[anchor] = defName
+		}
+	}
+	return bindings
+}
+
+// defaultBindings returns the bindings of a definition that is not referenced from another dynamic scope.
+func (t *translator) defaultBindings(defName string) map[string]string {
+	bindings := t.bindings
+	t.bindings = nil
+	defer func() {
+		t.bindings = bindings
+	}()
+	return t.enter(t.resourceOf(defName))
+}
+
+// refName returns the name of the definition in the current dynamic scope.
+// If the dynamic scope differs from the default bindings of the definition, then a specialized definition is created.
+func (t *translator) refName(defName string) string {
+	if len(t.dynamicAnchors) == 0 {
+		return defName
+	}
+	bindings := t.enter(t.resourceOf(defName))
+	if len(bindings) == 0 || maps.Equal(bindings, t.defaultBindings(defName)) {
+		return defName
+	}
+	scope := std.Map(std.SortedKeys(bindings), func(anchor string) string {
+		return anchor + "=" + bindings[anchor]
+	})
+	name := defName + "{" + strings.Join(scope, ",") + "}"
+	if _, ok := t.specializations[name]; !ok {
+		t.specializations[name] = &specialization{defName: defName, bindings: bindings}
+		t.pending = append(t.pending, name)
+	}
+	return name
+}
+
+func (t *translator) translateDynamicRef(parentId string, ref string) (*ast.Pattern, error) {
+	defName, err := t.dynamicRefToDefName(parentId, ref)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewReference(t.refName(defName)), nil
+}
+
+// dynamicRefToDefName returns the definition name that a $dynamicRef resolves to in the current dynamic scope.
+// A $dynamicRef only resolves dynamically if it initially resolves to a schema that declares a matching $dynamicAnchor,
+// otherwise it behaves like a $ref.
+func (t *translator) dynamicRefToDefName(parentId string, ref string) (string, error) {
+	anchor := refToAnchor(ref)
+	if len(anchor) == 0 {
+		return t.refToDefName(parentId, ref)
+	}
+	bound, ok := t.bindings[anchor]
+	if !ok {
+		return t.refToDefName(parentId, ref)
+	}
+	// the initial target has to exist, even if it is bound dynamically.
+	defName, err := t.refToDefName(parentId, ref)
+	if err != nil {
+		return "", err
+	}
+	if t.defs[defName].DynamicAnchor == anchor {
+		return bound, nil
+	}
+	return defName, nil
+}
+
+func (t *translator) translateRecursiveRef(parentId string, ref string) (*ast.Pattern, error) {
+	defName, err := t.recursiveRefToDefName(parentId, ref)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewReference(t.refName(defName)), nil
+}
+
+// recursiveRefToDefName returns the definition name that a $recursiveRef resolves to in the current dynamic scope.
+// A $recursiveRef of "#" initially resolves to the root of the current schema resource.
+// Only if that schema sets $recursiveAnchor to true, it resolves dynamically, otherwise it behaves like a $ref.
+func (t *translator) recursiveRefToDefName(parentId string, ref string) (string, error) {
+	defName, err := t.refToDefName(parentId, ref)
+	if err != nil {
+		return "", err
+	}
+	s, ok := t.defs[defName]
+	if !ok || s == nil {
+		return "", fmt.Errorf("could not resolve reference %s", ref)
+	}
+	if !s.RecursiveAnchor {
+		return defName, nil
+	}
+	if bound, ok := t.bindings[recursiveAnchor]; ok {
+		return bound, nil
+	}
+	return defName, nil
+}
@@ -0,0 +1,881 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	encjson "encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/katydid/parser-go-json/json"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/output"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+	"github.com/katydid/validator-go/validator/ast"
+	"github.com/katydid/validator-go/validator/intern"
+)
+
+// explainedRoot is the definition name of the root schema in the grammars of the explainer,
+// since main is the pattern of the keyword that is being matched.
+const explainedRoot = "main (explained)"
+
+// Explainer explains why a value does not match a schema.
+// Explaining is a lot slower than matching, so it is meant to be used after a value did not match.
+// Each subschema is first matched as a whole against the part of the value that it applies to.
+// Only if it does not match, each of its keywords is matched on its own and the keywords that do not match are explained further,
+// by evaluating the subschemas that they apply to the parts of the value.
+type Explainer struct {
+	t    *translator
+	root *schema.Schema
+	// refs are the translated definitions, where the root schema is named explainedRoot.
+	refs        map[string]*ast.Pattern
+	uniqueItems *UniqueItems
+	patterns    map[string]*regexp.Regexp
+	// grammars caches the grammar of each schema that is matched, since a schema is matched against many values.
+	grammars map[matchKey]*ast.Grammar
+	// derived caches the schemas that are derived from a schema to match only some of its keywords, so that their grammars are also cached.
+	derived map[derivedKey]*schema.Schema
+}
+
+// matchKey identifies the grammar of a schema, which also depends on the base URI and the dynamic scope that the schema is translated with.
+type matchKey struct {
+	s        *schema.Schema
+	parentId string
+	bindings string
+}
+
+// derivedKey identifies a schema that is derived from a schema to match only some of its keywords.
+type derivedKey struct {
+	s       *schema.Schema
+	keyword string
+}
+
+// NewExplainer parses the schema and returns an Explainer, which accepts the same options as NewGrammar.
+func NewExplainer(schemaStr []byte, version schema.Version, opts ...Option) (*Explainer, error) {
+	s, err := schema.ParseSchema(schemaStr)
+	if err != nil {
+		return nil, err
+	}
+	s.SetDefaultVersion(version)
+	return NewExplainerFromSchema(s, opts...)
+}
+
+// NewExplainerFromSchema returns an Explainer for a parsed schema.
+func NewExplainerFromSchema(s *schema.Schema, opts ...Option) (*Explainer, error) {
+	o := newOptions(opts)
+	t, refs, err := newDefinitions(s, o)
+	if err != nil {
+		return nil, err
+	}
+	e := &Explainer{
+		t:           t,
+		root:        s,
+		refs:        make(map[string]*ast.Pattern, len(refs)),
+		uniqueItems: o.uniqueItems,
+		patterns:    make(map[string]*regexp.Regexp),
+		grammars:    make(map[matchKey]*ast.Grammar),
+		derived:     make(map[derivedKey]*schema.Schema),
+	}
+	refs[explainedRoot] = refs["main"]
+	delete(refs, "main")
+	e.addRefs(refs)
+	bindContents(e, t.contentKeys)
+	return e, nil
+}
+
+// addRefs adds the definitions, where references to main are renamed to explainedRoot.
+func (e *Explainer) addRefs(refs map[string]*ast.Pattern) {
+	for name, p := range refs {
+		p.Walk(renameRef{from: "main", to: explainedRoot})
+		e.refs[name] = p
+	}
+}
+
+// renameRef renames the references to from to references to to.
+type renameRef struct {
+	from string
+	to   string
+}
+
+func (r renameRef) Visit(node interface{}) interface{} {
+	p, ok := node.(*ast.Pattern)
+	if ok && p.Reference != nil && p.Reference.GetName() == r.from {
+		p.Reference = ast.NewReference(r.to).Reference
+	}
+	return r
+}
+
+// Explain returns the verbose output of validating the value against the schema.
+// Subschemas that are valid are not explained further, so their units do not contain nested units.
+func (e *Explainer) Explain(value []byte) (*output.Unit, error) {
+	var v any
+	if err := std.UnmarshalJSON(value, &v); err != nil {
+		return nil, err
+	}
+	sc := explainScope{base: e.t.rootId}
+	return e.evaluate(sc, e.root, v)
+}
+
+// explainScope is the location of a keyword in the schema and of the value that it applies to.
+type explainScope struct {
+	// parentId is the base URI that the schema is translated with.
+	parentId string
+	// bindings is the dynamic scope of the schema.
+	bindings        map[string]string
+	keywordLocation string
+	// base is the URI of the schema resource or of the last reference that was followed and pointer is the json pointer from there.
+	base             string
+	pointer          string
+	instanceLocation string
+}
+
+// keyword returns the scope of the keyword or of the subschema of a keyword.
+func (sc explainScope) keyword(tokens ...string) explainScope {
+	for _, token := range tokens {
+		sc.keywordLocation += "/" + escapePointerToken(token)
+		sc.pointer += "/" + escapePointerToken(token)
+	}
+	return sc
+}
+
+// instance returns the scope of the value of the property or item.
+func (sc explainScope) instance(token string) explainScope {
+	sc.instanceLocation += "/" + escapePointerToken(token)
+	return sc
+}
+
+func (sc explainScope) unit(valid bool) *output.Unit {
+	u := &output.Unit{
+		Valid:            valid,
+		KeywordLocation:  sc.keywordLocation,
+		InstanceLocation: sc.instanceLocation,
+	}
+	if isAbsoluteURI(sc.base) {
+		u.AbsoluteKeywordLocation = sc.base + "#" + sc.pointer
+	}
+	return u
+}
+
+// evaluate returns the unit of the schema, which contains a unit for each keyword if the schema does not match.
+func (e *Explainer) evaluate(sc explainScope, s *schema.Schema, value any) (*output.Unit, error) {
+	if s.Bool != nil {
+		u := sc.unit(*s.Bool)
+		if !*s.Bool {
+			u.Error = "false schema does not allow any value"
+		}
+		return u, nil
+	}
+	// the value is encoded once, since it is matched against the schema and each of its keywords.
+	data, err := encjson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	valid, err := e.matchEncoded(sc.parentId, sc.bindings, s, value, data)
+	if err != nil {
+		return nil, err
+	}
+	u := sc.unit(valid)
+	if valid {
+		return u, nil
+	}
+	sc = e.enterId(sc, s)
+	for _, keyword := range keywordsOf(s) {
+		child, err := e.evaluateKeyword(sc, s, keyword, value, data)
+		if err != nil {
+			return nil, err
+		}
+		u.Add(child)
+	}
+	return u, nil
+}
+
+// enterId returns the scope of the schema, where a subschema with an id starts a new schema resource, which enters the dynamic scope.
+func (e *Explainer) enterId(sc explainScope, s *schema.Schema) explainScope {
+	if id := getId(sc.parentId, s); id != sc.parentId {
+		e.t.bindings = sc.bindings
+		sc.bindings = e.t.enter(id)
+		sc.parentId = id
+		sc.base, sc.pointer = id, ""
+	}
+	return sc
+}
+
+// evaluateKeyword returns the unit of the keyword, which contains the units of its subschemas if the keyword does not match.
+func (e *Explainer) evaluateKeyword(sc explainScope, s *schema.Schema, keyword string, value any, data []byte) (*output.Unit, error) {
+	valid, err := e.matchKeyword(sc, s, keyword, value, data)
+	if err != nil {
+		return nil, err
+	}
+	ksc := sc.keyword(keyword)
+	u := ksc.unit(valid)
+	if valid {
+		return u, nil
+	}
+	u.Error = explainKeyword(s, keyword, value)
+	children, err := e.evaluateSubschemas(ksc, s, keyword, value)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		u.Add(child)
+	}
+	return u, nil
+}
+
+// matchKeyword matches the encoded value against only the keyword of the schema.
+func (e *Explainer) matchKeyword(sc explainScope, s *schema.Schema, keyword string, value any, data []byte) (bool, error) {
+	if keyword == "unevaluatedProperties" || keyword == "unevaluatedItems" {
+		// unevaluated keywords depend on all the other keywords,
+		// so they only do not match if the schema matches without them.
+		without := e.derivedSchema(s, "without unevaluated", func() *schema.Schema {
+			without := withoutId(s)
+			without.UnevaluatedProperties = nil
+			without.UnevaluatedItems = nil
+			return without
+		})
+		valid, err := e.matchEncoded(sc.parentId, sc.bindings, without, value, data)
+		if err != nil || !valid {
+			return true, err
+		}
+		with := e.derivedSchema(s, keyword, func() *schema.Schema {
+			with := withoutId(s)
+			if keyword == "unevaluatedProperties" {
+				with.UnevaluatedItems = nil
+			} else {
+				with.UnevaluatedProperties = nil
+			}
+			return with
+		})
+		return e.matchEncoded(sc.parentId, sc.bindings, with, value, data)
+	}
+	k := e.derivedSchema(s, keyword, func() *schema.Schema {
+		return keywordSchema(s, keyword)
+	})
+	return e.matchEncoded(sc.parentId, sc.bindings, k, value, data)
+}
+
+// derivedSchema returns the schema that is derived from the schema for the keyword, which is only derived once.
+func (e *Explainer) derivedSchema(s *schema.Schema, keyword string, derive func() *schema.Schema) *schema.Schema {
+	key := derivedKey{s: s, keyword: keyword}
+	d, ok := e.derived[key]
+	if !ok {
+		d = derive()
+		e.derived[key] = d
+	}
+	return d
+}
+
+// match matches the value against the schema, including the uniqueItems that are validated after the grammar.
+func (e *Explainer) match(parentId string, bindings map[string]string, s *schema.Schema, value any) (bool, error) {
+	data, err := encjson.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	return e.matchEncoded(parentId, bindings, s, value, data)
+}
+
+// matchEncoded matches the value, which is encoded as data, against the schema, including the uniqueItems that are validated after the grammar.
+func (e *Explainer) matchEncoded(parentId string, bindings map[string]string, s *schema.Schema, value any, data []byte) (bool, error) {
+	g, err := e.grammar(parentId, bindings, s)
+	if err != nil {
+		return false, err
+	}
+	parser := json.NewJSONSchemaParser()
+	parser.Init(data)
+	valid, err := intern.Interpret(g, true, parser)
+	if err != nil || !valid {
+		return valid, err
+	}
+	if e.uniqueItems != nil && e.uniqueItems.HasUniqueItems() {
+		valid, _, err := e.uniqueItems.validate(parentId, s, value, make(map[*schema.Schema]bool))
+		return valid, err
+	}
+	return true, nil
+}
+
+// grammar returns the grammar of the schema, which is only translated the first time that the schema is matched with the base URI and the dynamic scope.
+func (e *Explainer) grammar(parentId string, bindings map[string]string, s *schema.Schema) (*ast.Grammar, error) {
+	key := matchKey{s: s, parentId: parentId, bindings: bindingsKey(bindings)}
+	if g, ok := e.grammars[key]; ok {
+		return g, nil
+	}
+	e.t.bindings = bindings
+	p, err := e.t.translate(parentId, s)
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[string]*ast.Pattern)
+	if err := e.t.translatePending(pending); err != nil {
+		return nil, err
+	}
+	e.addRefs(pending)
+	// the nested grammars are owned by the explainer, since the pending definitions are kept in its refs.
+	bindContents(e, e.t.registerContents(e.refs, explainedRoot))
+	p.Walk(renameRef{from: "main", to: explainedRoot})
+	refs := maps.Clone(e.refs)
+	refs["main"] = p
+	g := ast.NewGrammar(ast.RefLookup(refs))
+	e.grammars[key] = g
+	return g, nil
+}
+
+// bindingsKey returns a key for the dynamic scope.
+func bindingsKey(bindings map[string]string) string {
+	var key strings.Builder
+	for _, name := range std.SortedKeys(bindings) {
+		key.WriteString(strconv.Quote(name))
+		key.WriteString(strconv.Quote(bindings[name]))
+	}
+	return key.String()
+}
+
+// evaluateSubschemas returns the units of the subschemas that the keyword applies to the parts of the value.
+func (e *Explainer) evaluateSubschemas(sc explainScope, s *schema.Schema, keyword string, value any) ([]*output.Unit, error) {
+	var units []*output.Unit
+	if keyword == "properties" && s.GetVersion() == schema.VersionDraft3 {
+		obj, _ := value.(map[string]any)
+		props := s.GetProperties()
+		for _, name := range std.SortedKeys(props) {
+			if _, ok := obj[name]; !ok && obj != nil && props[name].RequiredProperty {
+				u := sc.keyword(name, "required").unit(false)
+				u.Error = fmt.Sprintf("missing required property %s", strconv.Quote(name))
+				units = append(units, u)
+			}
+		}
+	}
+	err := e.applySubschemas(sc, s, keyword, value, func(sc explainScope, s *schema.Schema, value any) error {
+		u, err := e.evaluate(sc, s, value)
+		if err != nil {
+			return err
+		}
+		units = append(units, u)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return units, nil
+}
+
+// applySubschemas calls apply for each subschema that the keyword applies to a part of the value, with the scope of the subschema and the part of the value.
+func (e *Explainer) applySubschemas(sc explainScope, s *schema.Schema, keyword string, value any, apply func(sc explainScope, s *schema.Schema, value any) error) error {
+	evaluate := func(sc explainScope, s *schema.Schema, value any) error {
+		if s == nil {
+			return nil
+		}
+		return apply(sc, s, value)
+	}
+	obj, _ := value.(map[string]any)
+	arr, _ := value.([]any)
+	switch keyword {
+	case "$ref", "$dynamicRef", "$recursiveRef":
+		sc, sch, err := e.followRef(sc, s, keyword)
+		if err != nil {
+			return err
+		}
+		return evaluate(sc, sch, value)
+	case "properties":
+		props := s.GetProperties()
+		for _, name := range std.SortedKeys(props) {
+			v, ok := obj[name]
+			if !ok {
+				continue
+			}
+			if err := evaluate(sc.keyword(name).instance(name), props[name], v); err != nil {
+				return err
+			}
+		}
+	case "patternProperties":
+		for _, pattern := range std.SortedKeys(s.PatternProperties) {
+			r, err := e.regexp(pattern)
+			if err != nil {
+				return err
+			}
+			for _, name := range std.SortedKeys(obj) {
+				if !r.MatchString(name) {
+					continue
+				}
+				if err := evaluate(sc.keyword(pattern).instance(name), s.PatternProperties[pattern], obj[name]); err != nil {
+					return err
+				}
+			}
+		}
+	case "additionalProperties":
+		for _, name := range std.SortedKeys(obj) {
+			additional, err := e.isAdditionalProperty(s, name)
+			if err != nil {
+				return err
+			}
+			if !additional {
+				continue
+			}
+			if err := evaluate(sc.instance(name), additionalSchema(s.AdditionalProperties), obj[name]); err != nil {
+				return err
+			}
+		}
+	case "propertyNames":
+		for _, name := range std.SortedKeys(obj) {
+			if err := evaluate(sc.instance(name), s.PropertyNames, name); err != nil {
+				return err
+			}
+		}
+	case "dependencies":
+		for _, name := range std.SortedKeys(*s.Dependencies) {
+			if _, ok := obj[name]; ok {
+				if err := evaluate(sc.keyword(name), (*s.Dependencies)[name].Schema, value); err != nil {
+					return err
+				}
+			}
+		}
+	case "dependentSchemas":
+		for _, name := range std.SortedKeys(s.DependentSchemas) {
+			if _, ok := obj[name]; ok {
+				if err := evaluate(sc.keyword(name), s.DependentSchemas[name], value); err != nil {
+					return err
+				}
+			}
+		}
+	case "items":
+		tuple := s.Items.GetArray()
+		start := 0
+		if s.GetVersion() >= schema.VersionDraft2020 {
+			start = len(s.PrefixItems)
+		}
+		for i := start; i < len(arr); i++ {
+			index := strconv.Itoa(i)
+			if sch := s.Items.GetObject(); sch != nil {
+				if err := evaluate(sc.instance(index), sch, arr[i]); err != nil {
+					return err
+				}
+			} else if i < len(tuple) {
+				if err := evaluate(sc.keyword(index).instance(index), tuple[i], arr[i]); err != nil {
+					return err
+				}
+			}
+		}
+	case "prefixItems":
+		for i := 0; i < len(arr) && i < len(s.PrefixItems); i++ {
+			index := strconv.Itoa(i)
+			if err := evaluate(sc.keyword(index).instance(index), s.PrefixItems[i], arr[i]); err != nil {
+				return err
+			}
+		}
+	case "additionalItems":
+		for i := len(s.Items.GetArray()); i < len(arr); i++ {
+			if err := evaluate(sc.instance(strconv.Itoa(i)), additionalSchema(s.AdditionalItems), arr[i]); err != nil {
+				return err
+			}
+		}
+	case "allOf", "anyOf", "oneOf", "extends":
+		schs := map[string][]*schema.Schema{"allOf": s.AllOf, "anyOf": s.AnyOf, "oneOf": s.OneOf, "extends": s.Extends.GetArray()}[keyword]
+		if sch := s.Extends.GetObject(); keyword == "extends" && sch != nil {
+			return evaluate(sc, sch, value)
+		}
+		for i, sch := range schs {
+			if err := evaluate(sc.keyword(strconv.Itoa(i)), sch, value); err != nil {
+				return err
+			}
+		}
+	case "not":
+		return evaluate(sc, s.Not, value)
+	case "then":
+		return evaluate(sc, s.Then, value)
+	case "else":
+		return evaluate(sc, s.Else, value)
+	}
+	return nil
+}
+
+// followRef returns the schema that the reference keyword resolves to and the scope that it is evaluated in.
+func (e *Explainer) followRef(sc explainScope, s *schema.Schema, keyword string) (explainScope, *schema.Schema, error) {
+	defName, err := e.refToDefName(sc, s, keyword)
+	if err != nil {
+		return sc, nil, err
+	}
+	uri := defName
+	if defName == "main" {
+		uri = e.t.rootId
+	}
+	sc.base, sc.pointer = splitFragment(uri)
+	sc.parentId = e.t.bases[defName]
+	e.t.bindings = sc.bindings
+	sc.bindings = e.t.enter(e.t.resourceOf(defName))
+	return sc, e.t.defs[defName], nil
+}
+
+// refToDefName returns the definition name that the reference keyword resolves to in the dynamic scope.
+func (e *Explainer) refToDefName(sc explainScope, s *schema.Schema, keyword string) (string, error) {
+	e.t.bindings = sc.bindings
+	switch keyword {
+	case "$dynamicRef":
+		return e.t.dynamicRefToDefName(sc.parentId, s.DynamicRef)
+	case "$recursiveRef":
+		return e.t.recursiveRefToDefName(sc.parentId, s.RecursiveRef)
+	}
+	return e.t.refToDefName(sc.parentId, s.Ref)
+}
+
+func (e *Explainer) regexp(pattern string) (*regexp.Regexp, error) {
+	if r, ok := e.patterns[pattern]; ok {
+		return r, nil
+	}
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	e.patterns[pattern] = r
+	return r, nil
+}
+
+// isAdditionalProperty returns whether the property does not match properties or patternProperties.
+func (e *Explainer) isAdditionalProperty(s *schema.Schema, name string) (bool, error) {
+	if _, ok := s.GetProperties()[name]; ok {
+		return false, nil
+	}
+	for pattern := range s.PatternProperties {
+		r, err := e.regexp(pattern)
+		if err != nil {
+			return false, err
+		}
+		if r.MatchString(name) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// keywordsOf returns the keywords of the schema that can be matched on their own.
+// Keywords that only have an effect together with another keyword are matched with that keyword,
+// for example minContains with contains and then with if.
+func keywordsOf(s *schema.Schema) []string {
+	if len(s.Ref) > 0 && s.GetVersion() <= schema.VersionDraft7 {
+		// before draft version 7 ref silently ignores siblings
+		return []string{"$ref"}
+	}
+	var keywords []string
+	add := func(present bool, keyword string) {
+		if present {
+			keywords = append(keywords, keyword)
+		}
+	}
+	add(len(s.Ref) > 0, "$ref")
+	add(len(s.DynamicRef) > 0, "$dynamicRef")
+	add(len(s.RecursiveRef) > 0, "$recursiveRef")
+	add(s.Type != nil || s.TypeUnion != nil, "type")
+	add(s.Enum != nil, "enum")
+	add(s.Const.Value != nil, "const")
+	add(s.MultipleOf != nil, "multipleOf")
+	add(s.DivisibleBy != nil, "divisibleBy")
+	add(s.Maximum != nil, "maximum")
+	add(s.ExclusiveMaximum.GetNumber() != nil, "exclusiveMaximum")
+	add(s.Minimum != nil, "minimum")
+	add(s.ExclusiveMinimum.GetNumber() != nil, "exclusiveMinimum")
+	add(s.MaxLength != nil, "maxLength")
+	add(s.MinLength > 0, "minLength")
+	add(s.Pattern != nil, "pattern")
+	add(len(s.Format) > 0, "format")
+	add(s.HasContentConstraints(), "contentMediaType")
+	add(s.Items != nil, "items")
+	add(s.PrefixItems != nil, "prefixItems")
+	add(s.AdditionalItems != nil && s.Items.GetArray() != nil, "additionalItems")
+	add(s.MaxItems != nil, "maxItems")
+	add(s.MinItems > 0, "minItems")
+	add(s.UniqueItems, "uniqueItems")
+	add(s.Contains != nil, "contains")
+	add(s.MaxProperties != nil, "maxProperties")
+	add(s.MinProperties > 0, "minProperties")
+	add(s.Required != nil, "required")
+	add(s.Properties != nil, "properties")
+	add(s.PatternProperties != nil, "patternProperties")
+	add(s.AdditionalProperties != nil, "additionalProperties")
+	add(s.Dependencies != nil, "dependencies")
+	add(s.DependentRequired != nil, "dependentRequired")
+	add(s.DependentSchemas != nil, "dependentSchemas")
+	add(s.PropertyNames != nil, "propertyNames")
+	add(s.AllOf != nil, "allOf")
+	add(s.AnyOf != nil, "anyOf")
+	add(s.OneOf != nil, "oneOf")
+	add(s.Not != nil, "not")
+	add(s.If != nil && s.Then != nil, "then")
+	add(s.If != nil && s.Else != nil, "else")
+	add(s.Extends != nil, "extends")
+	add(s.Disallow != nil, "disallow")
+	add(s.UnevaluatedItems != nil, "unevaluatedItems")
+	add(s.UnevaluatedProperties != nil, "unevaluatedProperties")
+	return keywords
+}
+
+// keywordSchema returns a schema that only contains the keyword and the keywords that it depends on,
+// where the subschemas of the keywords that it depends on are replaced by empty schemas.
+func keywordSchema(s *schema.Schema, keyword string) *schema.Schema {
+	k := &schema.Schema{Schema: s.Schema, Dialect: s.Dialect}
+	switch keyword {
+	case "$ref":
+		k.Ref = s.Ref
+	case "$dynamicRef":
+		k.DynamicRef = s.DynamicRef
+	case "$recursiveRef":
+		k.RecursiveRef = s.RecursiveRef
+	case "type":
+		k.Type = s.Type
+		k.TypeUnion = s.TypeUnion
+	case "enum":
+		k.Enum = s.Enum
+	case "const":
+		k.Const = s.Const
+	case "multipleOf":
+		k.MultipleOf = s.MultipleOf
+	case "divisibleBy":
+		k.DivisibleBy = s.DivisibleBy
+	case "maximum":
+		k.Maximum = s.Maximum
+		if s.ExclusiveMaximum.IsExclusive() {
+			k.ExclusiveMaximum = s.ExclusiveMaximum
+		}
+	case "exclusiveMaximum":
+		k.ExclusiveMaximum = s.ExclusiveMaximum
+	case "minimum":
+		k.Minimum = s.Minimum
+		if s.ExclusiveMinimum.IsExclusive() {
+			k.ExclusiveMinimum = s.ExclusiveMinimum
+		}
+	case "exclusiveMinimum":
+		k.ExclusiveMinimum = s.ExclusiveMinimum
+	case "maxLength":
+		k.MaxLength = s.MaxLength
+	case "minLength":
+		k.MinLength = s.MinLength
+	case "pattern":
+		k.Pattern = s.Pattern
+	case "format":
+		k.Format = s.Format
+	case "contentMediaType":
+		k.ContentEncoding = s.ContentEncoding
+		k.ContentMediaType = s.ContentMediaType
+		k.ContentSchema = s.ContentSchema
+	case "items":
+		k.Items = s.Items
+		k.PrefixItems = emptySchemas(len(s.PrefixItems))
+	case "prefixItems":
+		k.PrefixItems = s.PrefixItems
+	case "additionalItems":
+		k.AdditionalItems = s.AdditionalItems
+		k.Items = &schema.Items{Array: emptySchemas(len(s.Items.GetArray()))}
+	case "maxItems":
+		k.MaxItems = s.MaxItems
+	case "minItems":
+		k.MinItems = s.MinItems
+	case "uniqueItems":
+		k.UniqueItems = s.UniqueItems
+	case "contains":
+		k.Contains = s.Contains
+		k.MaxContains = s.MaxContains
+		k.MinContains = s.MinContains
+	case "maxProperties":
+		k.MaxProperties = s.MaxProperties
+	case "minProperties":
+		k.MinProperties = s.MinProperties
+	case "required":
+		k.Required = s.Required
+	case "properties":
+		k.Properties = s.Properties
+	case "patternProperties":
+		k.PatternProperties = s.PatternProperties
+	case "additionalProperties":
+		k.AdditionalProperties = s.AdditionalProperties
+		props := make(schema.Properties, len(s.GetProperties()))
+		for name := range s.GetProperties() {
+			props[name] = &schema.Schema{}
+		}
+		k.Properties = &props
+		k.PatternProperties = make(map[string]*schema.Schema, len(s.PatternProperties))
+		for pattern := range s.PatternProperties {
+			k.PatternProperties[pattern] = &schema.Schema{}
+		}
+	case "dependencies":
+		k.Dependencies = s.Dependencies
+	case "dependentRequired":
+		k.DependentRequired = s.DependentRequired
+	case "dependentSchemas":
+		k.DependentSchemas = s.DependentSchemas
+	case "propertyNames":
+		k.PropertyNames = s.PropertyNames
+	case "allOf":
+		k.AllOf = s.AllOf
+	case "anyOf":
+		k.AnyOf = s.AnyOf
+	case "oneOf":
+		k.OneOf = s.OneOf
+	case "not":
+		k.Not = s.Not
+	case "then":
+		k.If = s.If
+		k.Then = s.Then
+	case "else":
+		k.If = s.If
+		k.Else = s.Else
+	case "extends":
+		k.Extends = s.Extends
+	case "disallow":
+		k.Disallow = s.Disallow
+	}
+	return k
+}
+
+func emptySchemas(n int) []*schema.Schema {
+	if n == 0 {
+		return nil
+	}
+	schs := make([]*schema.Schema, n)
+	for i := range schs {
+		schs[i] = &schema.Schema{}
+	}
+	return schs
+}
+
+// withoutId returns a copy of the schema without its id, since the schema resource was already entered.
+func withoutId(s *schema.Schema) *schema.Schema {
+	c := *s
+	c.Id = ""
+	c.DollarId = ""
+	return &c
+}
+
+// explainKeyword returns why the value does not match the keyword.
+func explainKeyword(s *schema.Schema, keyword string, value any) string {
+	switch keyword {
+	case "$ref", "$dynamicRef", "$recursiveRef":
+		return "value does not match the referenced schema"
+	case "type":
+		if s.Type != nil {
+			return fmt.Sprintf("expected %s, but got %s", strings.Join(std.Map(*s.Type, func(t schema.SimpleType) string { return string(t) }), " or "), typeOf(value))
+		}
+		return fmt.Sprintf("value of type %s does not match type", typeOf(value))
+	case "enum":
+		return "value is not one of the values of enum"
+	case "const":
+		return "value is not equal to const"
+	case "multipleOf":
+		return fmt.Sprintf("value is not a multiple of %v", *s.MultipleOf)
+	case "divisibleBy":
+		return fmt.Sprintf("value is not divisible by %v", *s.DivisibleBy)
+	case "maximum":
+		if s.ExclusiveMaximum.IsExclusive() {
+			return fmt.Sprintf("value must be less than %s", numberString(s.Maximum))
+		}
+		return fmt.Sprintf("value must be less than or equal to %s", numberString(s.Maximum))
+	case "exclusiveMaximum":
+		return fmt.Sprintf("value must be less than %s", numberString(s.ExclusiveMaximum.GetNumber()))
+	case "minimum":
+		if s.ExclusiveMinimum.IsExclusive() {
+			return fmt.Sprintf("value must be greater than %s", numberString(s.Minimum))
+		}
+		return fmt.Sprintf("value must be greater than or equal to %s", numberString(s.Minimum))
+	case "exclusiveMinimum":
+		return fmt.Sprintf("value must be greater than %s", numberString(s.ExclusiveMinimum.GetNumber()))
+	case "maxLength":
+		return fmt.Sprintf("string is longer than %d characters", *s.MaxLength)
+	case "minLength":
+		return fmt.Sprintf("string is shorter than %d characters", s.MinLength)
+	case "pattern":
+		return fmt.Sprintf("string does not match pattern %s", *s.Pattern)
+	case "format":
+		return fmt.Sprintf("string is not a valid %s", s.Format)
+	case "contentMediaType":
+		return "string does not match its content encoding, media type or schema"
+	case "items", "prefixItems", "additionalItems":
+		return fmt.Sprintf("some items do not match %s", keyword)
+	case "maxItems":
+		return fmt.Sprintf("array has more than %d items", *s.MaxItems)
+	case "minItems":
+		return fmt.Sprintf("array has fewer than %d items", s.MinItems)
+	case "uniqueItems":
+		return "array items are not unique"
+	case "contains":
+		return "array does not contain the required number of items that match contains"
+	case "maxProperties":
+		return fmt.Sprintf("object has more than %d properties", *s.MaxProperties)
+	case "minProperties":
+		return fmt.Sprintf("object has fewer than %d properties", s.MinProperties)
+	case "required":
+		obj, _ := value.(map[string]any)
+		var missing []string
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				missing = append(missing, strconv.Quote(name))
+			}
+		}
+		return fmt.Sprintf("missing required properties %s", strings.Join(missing, ", "))
+	case "properties", "patternProperties", "additionalProperties":
+		return fmt.Sprintf("some properties do not match %s", keyword)
+	case "dependencies", "dependentRequired", "dependentSchemas":
+		return fmt.Sprintf("some properties do not satisfy %s", keyword)
+	case "propertyNames":
+		return "some property names do not match propertyNames"
+	case "allOf", "extends":
+		return fmt.Sprintf("value does not match all the schemas of %s", keyword)
+	case "anyOf":
+		return "value does not match any of the schemas of anyOf"
+	case "oneOf":
+		return "value does not match exactly one of the schemas of oneOf"
+	case "not":
+		return "value matches the schema of not"
+	case "then":
+		return "value matches if, but does not match then"
+	case "else":
+		return "value does not match if or else"
+	case "disallow":
+		return fmt.Sprintf("value of type %s is disallowed", typeOf(value))
+	case "unevaluatedItems":
+		return "some items that are not evaluated do not match unevaluatedItems"
+	case "unevaluatedProperties":
+		return "some properties that are not evaluated do not match unevaluatedProperties"
+	}
+	return fmt.Sprintf("value does not match %s", keyword)
+}
+
+// typeOf returns the JSON Schema type of the value.
+func typeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case encjson.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	}
+	return "object"
+}
+
+// numberString returns the number as it is written in the schema.
+func numberString(n *schema.Number) string {
+	if f := n.GetFloat(); f != nil {
+		return strconv.FormatFloat(*f, 'g', -1, 64)
+	}
+	if f := n.GetBigFloat(); f != nil {
+		return *f
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+func TestExplainerTranslatesEachSchemaOnce(t *testing.T) {
+	e, err := NewExplainer([]byte(`{
+		"properties": {
+			"a": {"type": "integer", "minimum": 1},
+			"b": {"$ref": "#/$defs/b"}
+		},
+		"$defs": {"b": {"type": "string", "maxLength": 2}},
+		"unevaluatedProperties": false
+	}`), schema.VersionDraft2020)
+	if err != nil {
+		t.Fatal(err)
+	}
+	explain := func(value string) {
+		t.Helper()
+		u, err := e.Explain([]byte(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Valid {
+			t.Fatalf("expected %s to not be valid", value)
+		}
+	}
+	explain(`{"a": 0, "b": "abc", "c": 1}`)
+	grammars, derived := len(e.grammars), len(e.derived)
+	explain(`{"a": -1, "b": "abcd", "c": 2}`)
+	if len(e.grammars) != grammars || len(e.derived) != derived {
+		t.Fatalf("expected the same schemas to be translated once, but got %d grammars instead of %d and %d derived schemas instead of %d", len(e.grammars), grammars, len(e.derived), derived)
+	}
+}
@@ -16,47 +16,33 @@ package translate
 
 import "github.com/katydid/validator-go/validator/ast"
 
+// formatExprs are the formats that are validated, with the functions that return their expressions.
+var formatExprs = map[string]func() *ast.Expr{
+	"date":                  dateExpr,
+	"date-time":             datetimeExpr,
+	"email":                 emailExpr,
+	"hostname":              hostNameExpr,
+	"json-pointer":          jsonPointerExpr,
+	"relative-json-pointer": relativeJSONPointerExpr,
+	"uuid":                  uuidExpr,
+	"duration":              durationExpr,
+	"ipv4":                  ipv4Expr,
+	"ipv6":                  ipv6Expr,
+	"time":                  timeExpr,
+	"uri":                   uriExpr,
+	"iri":                   iriExpr,
+	"uri-reference":         uriReferenceExpr,
+	"iri-reference":         iriReferenceExpr,
+	"uri-template":          uriTemplateExpr,
+	"period":                periodExpr,
+	"semver":                semverExpr,
+}
+
 func translateFormat(format string) (*ast.Expr, error) {
-	switch format {
-	case "date":
-		return dateExpr(), nil
-	case "date-time":
-		return datetimeExpr(), nil
-	case "email":
-		return emailExpr(), nil
-	case "hostname":
-		return hostNameExpr(), nil
-	case "json-pointer":
-		return jsonPointerExpr(), nil
-	case "relative-json-pointer":
-		return relativeJSONPointerExpr(), nil
-	case "uuid":
-		return uuidExpr(), nil
-	case "duration":
-		return durationExpr(), nil
-	case "ipv4":
-		return ipv4Expr(), nil
-	case "ipv6":
-		return ipv6Expr(), nil
-	case "time":
-		return timeExpr(), nil
-	case "uri":
-		return uriExpr(), nil
-	case "iri":
-		return iriExpr(), nil
-	case "uri-reference":
-		return uriReferenceExpr(), nil
-	case "iri-reference":
-		return iriReferenceExpr(), nil
-	case "uri-template":
-		return uriTemplateExpr(), nil
-	case "period":
-		return periodExpr(), nil
-	case "semver":
-		return semverExpr(), nil
-	default:
-		// A format attribute can generally only validate a given set of instance types.
-		// If the type of the instance to validate is not in this set, validation for this format attribute and instance SHOULD succeed.
-		return anyExpr(), nil
+	if formatExpr, ok := formatExprs[format]; ok {
+		return formatExpr(), nil
 	}
+	// A format attribute can generally only validate a given set of instance types.
+	// If the type of the instance to validate is not in this set, validation for this format attribute and instance SHOULD succeed.
+	return anyExpr(), nil
 }
@@ -142,6 +142,10 @@ func anyValueExpr() *ast.Expr {
 	return ast.NewFunction("anyValue")
 }
 
+func contentExpr(encoding string, mediaType string, contentSchema string) *ast.Expr {
+	return ast.NewFunction("content", combinator.StringConst(encoding), combinator.StringConst(mediaType), combinator.StringConst(contentSchema), combinator.StringVar())
+}
+
 func regexExpr(s string) *ast.Expr {
 	return ast.NewFunction("regex", combinator.StringConst(s), combinator.StringVar())
 }
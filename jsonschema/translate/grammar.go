@@ -19,13 +19,29 @@ import (
 	"github.com/katydid/validator-go/validator/ast"
 )
 
-func NewGrammar(schemaStr []byte, version schema.Version) (*ast.Grammar, error) {
+func NewGrammar(schemaStr []byte, version schema.Version, opts ...Option) (*ast.Grammar, error) {
+	o := newOptions(opts)
+	if o.metaSchemaValidation {
+		if err := ValidateMetaSchema(schemaStr, version); err != nil {
+			return nil, err
+		}
+	}
+	if o.strictKeywords {
+		if err := ValidateKeywords(schemaStr, version); err != nil {
+			return nil, err
+		}
+	}
 	s, err := schema.ParseSchema(schemaStr)
 	if err != nil {
 		return nil, err
 	}
 	s.SetDefaultVersion(version)
-	g, err := Translate(s)
+	return NewGrammarFromSchema(s, opts...)
+}
+
+// NewGrammarFromSchema translates a parsed schema and checks that all its references are defined.
+func NewGrammarFromSchema(s *schema.Schema, opts ...Option) (*ast.Grammar, error) {
+	g, err := Translate(s, opts...)
 	if err != nil {
 		return nil, err
 	}
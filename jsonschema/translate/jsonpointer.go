@@ -21,47 +21,12 @@ import (
 	"github.com/katydid/validator-go-jsonschema/jsonschema/translate/jsonpointer"
 )
 
-const reservedWordForEmpty = "reserved word for empty definition path"
-
-func parsePointer(s string) ([]string, error) {
-	var path []string
-	var err error
-	if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "file://") {
-		u, err := url.Parse(s)
-		if err != nil {
-			return nil, err
-		}
-		fragment := ""
-		if len(u.Fragment) == 0 {
-			fragment = ""
-		} else {
-			fragment = "/" + u.Fragment
-		}
-		fragmentPath, err := jsonpointer.ParseFragment(fragment)
-		if err != nil {
-			return nil, err
-		}
-		urlPath := strings.Split(u.Path, "/")
-		if urlPath[0] == "" {
-			urlPath = urlPath[1:]
-		}
-		prefix := u.Scheme + "://" + u.Host
-		path = append([]string{prefix}, append(urlPath, fragmentPath...)...)
-	} else if strings.HasPrefix(s, "#") {
-		// sometimes we forget to strip the hash from the front.
-		path, err = jsonpointer.ParseFragment(s[1:])
-		if err != nil {
-			return nil, err
-		}
-	} else if strings.HasPrefix(s, "/") {
-		path, err = jsonpointer.ParseFragment(s)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		path = []string{s}
+// parsePointer parses the json pointer in a URI fragment, for example "#/$defs/a", into its reference tokens.
+func parsePointer(fragment string) ([]string, error) {
+	path, err := jsonpointer.ParseFragment(strings.TrimPrefix(fragment, "#"))
+	if err != nil {
+		return nil, err
 	}
-
 	// This decodes the percent encoding, changing %25 to %
 	for i, p := range path {
 		u, err := url.PathUnescape(p)
@@ -70,10 +35,5 @@ func parsePointer(s string) ([]string, error) {
 			path[i] = u
 		}
 	}
-	for i, p := range path {
-		if p == "" {
-			path[i] = reservedWordForEmpty
-		}
-	}
 	return path, nil
 }
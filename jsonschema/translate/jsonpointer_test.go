@@ -35,10 +35,7 @@ func TestJSONPointer(t *testing.T) {
 	expect(`/definitions/percent%25field`, []string{"definitions", "percent%field"})
 	expect(`#/definitions/percent%25field`, []string{"definitions", "percent%field"})
 	expect(`#/definitions/percent%field`, []string{"definitions", "percent%field"})
-	expect(`#/definitions//definitions/`, []string{"definitions", reservedWordForEmpty, "definitions", reservedWordForEmpty})
+	expect(`#/definitions//definitions/`, []string{"definitions", "", "definitions", ""})
 	expect(`#`, []string{})
-	expect("http://json-schema.org/draft-04/schema", []string{"http://json-schema.org", "draft-04", "schema"})
-	expect("http://json-schema.org/draft-04/schema/", []string{"http://json-schema.org", "draft-04", "schema", reservedWordForEmpty})
-	expect("http://json-schema.org/draft-04/schema#", []string{"http://json-schema.org", "draft-04", "schema"})
-	expect("http://json-schema.org/draft-04/schema#abc", []string{"http://json-schema.org", "draft-04", "schema", "abc"})
+	expect(``, []string{})
 }
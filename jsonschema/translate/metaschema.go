@@ -0,0 +1,246 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	encjson "encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/katydid/parser-go-json/json"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+	"github.com/katydid/validator-go/validator/ast"
+	"github.com/katydid/validator-go/validator/intern"
+)
+
+// ErrInvalidSchema is returned when a schema is not valid according to its meta-schema.
+var ErrInvalidSchema = errors.New("schema is not valid according to its meta-schema")
+
+var (
+	metaSchemaGrammarsMu sync.Mutex
+	// metaSchemaGrammars caches the translated meta-schemas by their URI, since they are the same for every schema.
+	metaSchemaGrammars = make(map[string]*ast.Grammar)
+)
+
+// metaSchemaGrammar returns the grammar of the embedded meta-schema with the URI.
+func metaSchemaGrammar(uri string, v schema.Version) (*ast.Grammar, error) {
+	metaSchemaGrammarsMu.Lock()
+	defer metaSchemaGrammarsMu.Unlock()
+	if g, ok := metaSchemaGrammars[uri]; ok {
+		return g, nil
+	}
+	// the meta-schema is referenced, instead of translated directly, so that it is loaded like any other embedded meta-schema.
+	s := &schema.Schema{Ref: uri}
+	s.SetDefaultVersion(v)
+	g, err := NewGrammarFromSchema(s)
+	if err != nil {
+		return nil, fmt.Errorf("could not translate meta-schema %s: %w", uri, err)
+	}
+	metaSchemaGrammars[uri] = g
+	return g, nil
+}
+
+// ValidateMetaSchema validates the schema against the meta-schema of its version,
+// which is detected from $schema or otherwise is the default version.
+// If the schema is not valid, the error includes the location of the failing keyword as a json pointer.
+// Draft 3 schemas are not validated, since the Draft 3 meta-schema is not embedded.
+func ValidateMetaSchema(schemaStr []byte, defaultVersion schema.Version) error {
+	v := schema.DetectVersion(schemaStr, defaultVersion)
+	if v == schema.VersionDraft3 {
+		// the Draft 3 meta-schema is not embedded, so Draft 3 schemas are not validated.
+		return nil
+	}
+	uri := schema.MetaSchemaURI(v)
+	g, err := metaSchemaGrammar(uri, v)
+	if err != nil {
+		return err
+	}
+	var value any
+	if err := std.UnmarshalJSON(schemaStr, &value); err != nil {
+		return err
+	}
+	m := &metaSchemaMatcher{grammar: g}
+	valid, err := m.match(value)
+	if err != nil {
+		return err
+	}
+	if valid {
+		return nil
+	}
+	location, err := m.locate(value, "")
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w %s at #%s", ErrInvalidSchema, uri, location)
+}
+
+// ErrUnknownKeyword is returned when a schema contains a keyword that is not defined by the meta-schema of its version.
+var ErrUnknownKeyword = errors.New("schema contains a keyword that is not defined by its meta-schema")
+
+// ValidateKeywords returns an error that wraps ErrUnknownKeyword, if the schema or one of its subschemas contains a keyword,
+// like the misspelled "minimun", that is not defined by the meta-schema of its version.
+// The version is detected from $schema or otherwise is the default version.
+// The error includes the location of the unknown keyword as a json pointer.
+// Draft 3 schemas are not checked, since the Draft 3 meta-schema is not embedded.
+func ValidateKeywords(schemaStr []byte, defaultVersion schema.Version) error {
+	v := schema.DetectVersion(schemaStr, defaultVersion)
+	if v == schema.VersionDraft3 {
+		return nil
+	}
+	keywords, err := schema.Keywords(v)
+	if err != nil {
+		return err
+	}
+	var value any
+	if err := std.UnmarshalJSON(schemaStr, &value); err != nil {
+		return err
+	}
+	if pointer, ok := unknownKeyword(keywords, value, ""); ok {
+		return fmt.Errorf("%w at #%s", ErrUnknownKeyword, pointer)
+	}
+	return nil
+}
+
+// unknownKeyword returns the json pointer of the first keyword that is not one of the keywords.
+func unknownKeyword(keywords map[string]bool, value any, pointer string) (string, bool) {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	for _, keyword := range std.SortedKeys(obj) {
+		keywordPointer := pointer + "/" + escapePointerToken(keyword)
+		if !keywords[keyword] {
+			return keywordPointer, true
+		}
+		for _, sub := range subschemaValues(keyword, obj[keyword]) {
+			if p, ok := unknownKeyword(keywords, sub.value, keywordPointer+sub.pointer); ok {
+				return p, true
+			}
+		}
+	}
+	return "", false
+}
+
+// metaSchemaMatcher matches parts of a schema against a meta-schema.
+type metaSchemaMatcher struct {
+	grammar *ast.Grammar
+}
+
+func (m *metaSchemaMatcher) match(value any) (bool, error) {
+	data, err := encjson.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	p := json.NewJSONSchemaParser()
+	p.Init(data)
+	return intern.Interpret(m.grammar, true, p)
+}
+
+// locate returns the json pointer of the deepest keyword that is not valid.
+// Each subschema must on its own be valid according to the meta-schema,
+// so the invalid subschema is found first and then the keyword of that subschema that is not valid on its own.
+func (m *metaSchemaMatcher) locate(value any, pointer string) (string, error) {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return pointer, nil
+	}
+	keywords := std.SortedKeys(obj)
+	for _, keyword := range keywords {
+		for _, sub := range subschemaValues(keyword, obj[keyword]) {
+			valid, err := m.match(sub.value)
+			if err != nil {
+				return "", err
+			}
+			if !valid {
+				return m.locate(sub.value, pointer+"/"+escapePointerToken(keyword)+sub.pointer)
+			}
+		}
+	}
+	for _, keyword := range keywords {
+		valid, err := m.match(map[string]any{keyword: obj[keyword]})
+		if err != nil {
+			return "", err
+		}
+		if !valid {
+			return pointer + "/" + escapePointerToken(keyword), nil
+		}
+	}
+	return pointer, nil
+}
+
+// subschemaValue is a subschema with its json pointer relative to the keyword.
+type subschemaValue struct {
+	pointer string
+	value   any
+}
+
+// subschemaValues returns the subschemas of the keyword, if the keyword applies subschemas.
+func subschemaValues(keyword string, value any) []subschemaValue {
+	switch keyword {
+	case "additionalItems", "items", "contains", "unevaluatedItems", "contentSchema",
+		"additionalProperties", "propertyNames", "unevaluatedProperties",
+		"not", "if", "then", "else":
+		if arr, ok := value.([]any); ok && keyword == "items" {
+			return indexedSubschemas(arr)
+		}
+		if isSchemaValue(value) {
+			return []subschemaValue{{value: value}}
+		}
+	case "prefixItems", "allOf", "anyOf", "oneOf":
+		if arr, ok := value.([]any); ok {
+			return indexedSubschemas(arr)
+		}
+	case "definitions", "$defs", "properties", "patternProperties", "dependentSchemas", "dependencies":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		subs := []subschemaValue{}
+		for _, name := range std.SortedKeys(obj) {
+			if isSchemaValue(obj[name]) {
+				subs = append(subs, subschemaValue{pointer: "/" + escapePointerToken(name), value: obj[name]})
+			}
+		}
+		return subs
+	}
+	return nil
+}
+
+func indexedSubschemas(arr []any) []subschemaValue {
+	subs := []subschemaValue{}
+	for i, v := range arr {
+		if isSchemaValue(v) {
+			subs = append(subs, subschemaValue{pointer: "/" + strconv.Itoa(i), value: v})
+		}
+	}
+	return subs
+}
+
+// isSchemaValue returns whether the value is an object schema.
+// Boolean schemas are always valid, if they are allowed, so they never contain the failing keyword.
+func isSchemaValue(value any) bool {
+	_, ok := value.(map[string]any)
+	return ok
+}
+
+var pointerTokenEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func escapePointerToken(token string) string {
+	return pointerTokenEscaper.Replace(token)
+}
@@ -15,7 +15,6 @@
 package translate
 
 import (
-	"errors"
 	"regexp"
 	"slices"
 
@@ -24,7 +23,7 @@ import (
 	"github.com/katydid/validator-go/validator/ast"
 )
 
-func translateObject(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+func (t *translator) translateObject(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 	var constraints []*ast.Pattern
 	if s.MaxProperties != nil {
 		constraints = append(constraints, maxProperties(int(*s.MaxProperties)))
@@ -33,26 +32,15 @@ func translateObject(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 		constraints = append(constraints, minProperties(int(s.MinProperties)))
 	}
 
-	propertyNamesHandlesAdditional := false
-	if s.PropertyNames != nil {
-		// currently only pattern property names are supported.
-		if s.PropertyNames.Pattern != nil {
-			if s.PatternProperties == nil {
-				s.PatternProperties = make(map[string]*schema.Schema)
-			}
-			s.PatternProperties[*s.PropertyNames.Pattern] = &schema.Schema{}
-			if s.AdditionalProperties != nil {
-				if s.AdditionalProperties.Schema != nil {
-					propertyNamesHandlesAdditional = true
-					s.PatternProperties[*s.PropertyNames.Pattern] = s.AdditionalProperties.Schema
-				}
-			}
-		} else {
-			return nil, errors.New("propertyNames support is limited to pattern")
+	if s.PropertyNames != nil && s.GetVersion() >= schema.VersionDraft6 {
+		p, err := t.translatePropertyNames(parentId, s)
+		if err != nil {
+			return nil, err
 		}
+		constraints = append(constraints, p)
 	}
 
-	props, err := newProperties(parentId, s)
+	props, err := t.newProperties(parentId, s)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +58,7 @@ func translateObject(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 		constraints = append(constraints, p)
 		return newAnd(constraints...), nil
 	}
-	if len(s.Required) > 0 {
+	if len(requiredOf(s)) > 0 {
 		required, err := translateRequired(props)
 		if err != nil {
 			return nil, err
@@ -82,14 +70,10 @@ func translateObject(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 		return nil, err
 	}
 
-	additional, err := translateAdditionalProperties(parentId, s)
+	additional, err := t.translateAdditionalProperties(parentId, s)
 	if err != nil {
 		return nil, err
 	}
-	if propertyNamesHandlesAdditional {
-		// we handle additional properties in propertyNames, so we can ignore it.
-		additional = ast.NewEmpty()
-	}
 
 	if len(props) == 0 {
 		constraints = append(constraints, additional)
@@ -108,33 +92,32 @@ type property struct {
 	required bool
 }
 
-func newProperties(parentId string, s *schema.Schema) ([]*property, error) {
+func (t *translator) newProperties(parentId string, s *schema.Schema) ([]*property, error) {
 	names := std.SortedKeys(s.GetProperties())
 	patternNames := std.SortedKeys(s.PatternProperties)
 	props := make([]*property, 0, len(names)+len(patternNames))
-	requires := make([]string, len(s.Required))
-	copy(requires, s.Required)
+	requires := slices.Clone(requiredOf(s))
 	for _, name := range names {
 		index := slices.Index(requires, name)
 		required := index != -1
 		if required {
 			requires = slices.Delete(requires, index, index+1)
 		}
-		p, err := newProperty(getId(parentId, s), name, s.GetProperties()[name], required)
+		p, err := t.newProperty(getId(parentId, s), name, s.GetProperties()[name], required)
 		if err != nil {
 			return nil, err
 		}
 		props = append(props, p)
 	}
 	for _, name := range requires {
-		p, err := newProperty(getId(parentId, s), name, &schema.Schema{}, true)
+		p, err := t.newProperty(getId(parentId, s), name, &schema.Schema{}, true)
 		if err != nil {
 			return nil, err
 		}
 		props = append(props, p)
 	}
 	for _, name := range patternNames {
-		p, err := newPatternProperty(getId(parentId, s), name, s.PatternProperties[name])
+		p, err := t.newPatternProperty(getId(parentId, s), name, s.PatternProperties[name])
 		if err != nil {
 			return nil, err
 		}
@@ -143,8 +126,8 @@ func newProperties(parentId string, s *schema.Schema) ([]*property, error) {
 	return props, nil
 }
 
-func newProperty(parentId string, name string, s *schema.Schema, required bool) (*property, error) {
-	child, err := translate(parentId, s)
+func (t *translator) newProperty(parentId string, name string, s *schema.Schema, required bool) (*property, error) {
+	child, err := t.translate(parentId, s)
 	if err != nil {
 		return nil, err
 	}
@@ -156,8 +139,8 @@ func newProperty(parentId string, name string, s *schema.Schema, required bool)
 	}, nil
 }
 
-func newPatternProperty(parentId string, name string, s *schema.Schema) (*property, error) {
-	child, err := translate(parentId, s)
+func (t *translator) newPatternProperty(parentId string, name string, s *schema.Schema) (*property, error) {
+	child, err := t.translate(parentId, s)
 	if err != nil {
 		return nil, err
 	}
@@ -292,7 +275,7 @@ func minProperties(n int) *ast.Pattern {
 	return ast.NewConcat(ast.NewConcat(ps...), ast.NewZAny())
 }
 
-func translateAdditionalProperties(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+func (t *translator) translateAdditionalProperties(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 	additional := ast.NewZAny()
 
 	names := std.SortedKeys(s.GetProperties())
@@ -318,7 +301,7 @@ func translateAdditionalProperties(parentId string, s *schema.Schema) (*ast.Patt
 		if s.AdditionalProperties.Bool != nil && !(*s.AdditionalProperties.Bool) {
 			additional = ast.NewEmpty()
 		} else if s.AdditionalProperties.Schema != nil {
-			p, err := translate(getId(parentId, s), s.AdditionalProperties.Schema)
+			p, err := t.translate(getId(parentId, s), s.AdditionalProperties.Schema)
 			if err != nil {
 				return nil, err
 			}
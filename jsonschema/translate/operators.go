@@ -20,7 +20,7 @@ import (
 	"github.com/katydid/validator-go/validator/ast"
 )
 
-func translateOperators(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+func (t *translator) translateOperators(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 	var res []*ast.Pattern
 	if s.Enum != nil {
 		p, err := translateEnum(s.Enum)
@@ -30,56 +30,56 @@ func translateOperators(parentId string, s *schema.Schema) (*ast.Pattern, error)
 		res = append(res, p)
 	}
 	if len(s.AllOf) > 0 {
-		ps, err := std.MapErr(s.AllOf, translateWithParentId(getId(parentId, s)))
+		ps, err := std.MapErr(s.AllOf, t.translateWithParentId(getId(parentId, s)))
 		if err != nil {
 			return nil, err
 		}
 		res = append(res, newAnd(ps...))
 	}
 	if len(s.AnyOf) > 0 {
-		ps, err := std.MapErr(s.AnyOf, translateWithParentId(getId(parentId, s)))
+		ps, err := std.MapErr(s.AnyOf, t.translateWithParentId(getId(parentId, s)))
 		if err != nil {
 			return nil, err
 		}
 		res = append(res, newOr(ps...))
 	}
 	if len(s.OneOf) > 0 {
-		p, err := translateOneOf(getId(parentId, s), s.OneOf)
+		p, err := t.translateOneOf(getId(parentId, s), s.OneOf)
 		if err != nil {
 			return nil, err
 		}
 		res = append(res, p)
 	}
 	if s.Not != nil {
-		p, err := translate(getId(parentId, s), s.Not)
+		p, err := t.translate(getId(parentId, s), s.Not)
 		if err != nil {
 			return nil, err
 		}
 		res = append(res, ast.NewNot(p))
 	}
-	if s.If != nil {
-		p, err := translateIf(getId(parentId, s), s.If, s.Then, s.Else)
+	if s.If != nil && s.GetVersion() >= schema.VersionDraft7 {
+		p, err := t.translateIf(getId(parentId, s), s.If, s.Then, s.Else)
 		if err != nil {
 			return nil, err
 		}
 		res = append(res, p)
 	}
 	if s.Dependencies != nil {
-		deps, err := translateDependencies(getId(parentId, s), s.Dependencies)
+		deps, err := t.translateDependencies(getId(parentId, s), s.Dependencies)
 		if err != nil {
 			return nil, err
 		}
 		res = append(res, deps)
 	}
-	if s.DependentRequired != nil {
+	if s.DependentRequired != nil && s.GetVersion() >= schema.VersionDraft2019 {
 		deps, err := translateDependentRequired(s.DependentRequired)
 		if err != nil {
 			return nil, err
 		}
 		res = append(res, deps)
 	}
-	if s.DependentSchemas != nil {
-		deps, err := translateDependentSchemas(getId(parentId, s), s.DependentSchemas)
+	if s.DependentSchemas != nil && s.GetVersion() >= schema.VersionDraft2019 {
+		deps, err := t.translateDependentSchemas(getId(parentId, s), s.DependentSchemas)
 		if err != nil {
 			return nil, err
 		}
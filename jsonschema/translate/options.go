@@ -0,0 +1,95 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"github.com/katydid/validator-go-jsonschema/jsonschema/loader"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+type options struct {
+	contentAssertion     bool
+	metaSchemaValidation bool
+	strictKeywords       bool
+	loader               loader.Loader
+	resources            *Resources
+	uniqueItems          *UniqueItems
+	dialects             map[string]*schema.Dialect
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+type Option func(o *options)
+
+// WithContentAssertion validates contentEncoding, contentMediaType and contentSchema, which are otherwise only annotations.
+func WithContentAssertion() Option {
+	return func(o *options) {
+		o.contentAssertion = true
+	}
+}
+
+// WithLoader loads the documents of references to other documents, which are otherwise not supported.
+func WithLoader(l loader.Loader) Option {
+	return func(o *options) {
+		o.loader = l
+	}
+}
+
+// WithResources resolves references to the documents that were added to the resources, before they are loaded.
+func WithResources(r *Resources) Option {
+	return func(o *options) {
+		o.resources = r
+	}
+}
+
+// WithMetaSchemaValidation validates schemas against the meta-schema of their version before they are parsed.
+// This only applies to schemas that are translated from bytes.
+func WithMetaSchemaValidation() Option {
+	return func(o *options) {
+		o.metaSchemaValidation = true
+	}
+}
+
+// WithStrictKeywords returns an error for keywords that are not defined by the meta-schema of the schema's version, which are otherwise ignored.
+// This only applies to schemas that are translated from bytes.
+func WithStrictKeywords() Option {
+	return func(o *options) {
+		o.strictKeywords = true
+	}
+}
+
+// WithUniqueItems supports uniqueItems, which is otherwise not supported, by initialising u to validate uniqueItems after the automaton.
+// Only the uniqueItems in subschemas that always apply are supported.
+func WithUniqueItems(u *UniqueItems) Option {
+	return func(o *options) {
+		o.uniqueItems = u
+	}
+}
+
+// WithDialect registers a dialect for a $schema URI, which is then not resolved by loading its meta-schema.
+func WithDialect(uri string, d *schema.Dialect) Option {
+	return func(o *options) {
+		if o.dialects == nil {
+			o.dialects = make(map[string]*schema.Dialect)
+		}
+		o.dialects[uri] = d
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go/validator/ast"
+)
+
+// translatePropertyNames requires that the name of every property matches the propertyNames schema, for example:
+//
+//	"propertyNames": {"$ref": "#/$defs/identifier"},
+//	"$defs": {"identifier": {"pattern": "^[a-z]+$", "maxLength": 8}}
+//
+// Property names are not values, so the schema is translated to a name expression:
+//
+//	(~"^[a-z]+$" & ~"^[\s\S]{0,8}$"): *
+//
+// Property names are always strings, so only the keywords that apply to strings are translated.
+// The formats that are validated return an error, since the format functions only apply to values and not to names.
+func (t *translator) translatePropertyNames(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+	name, err := t.translateNameExpr(getId(parentId, s), s.PropertyNames, make(map[*schema.Schema]bool))
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewZeroOrMore(ast.NewTreeNode(name, ast.NewZAny())), nil
+}
+
+// translateNameExpr translates a schema to a name expression that matches all the strings that are valid according to the schema.
+func (t *translator) translateNameExpr(parentId string, s *schema.Schema, visited map[*schema.Schema]bool) (*ast.NameExpr, error) {
+	if s.Bool != nil {
+		if *s.Bool {
+			return ast.NewAnyName(), nil
+		}
+		return noName(), nil
+	}
+	if visited[s] {
+		return nil, fmt.Errorf("recursive propertyNames are not supported")
+	}
+	visited[s] = true
+	defer delete(visited, s)
+
+	id := getId(parentId, s)
+	names := []*ast.NameExpr{}
+	if len(s.Ref) > 0 {
+		defName, err := t.refToDefName(id, s.Ref)
+		if err != nil {
+			return nil, err
+		}
+		name, err := t.translateNameExpr(t.bases[defName], t.defs[defName], visited)
+		if err != nil {
+			return nil, err
+		}
+		if s.GetVersion() <= schema.VersionDraft7 {
+			// before draft version 7 ref silently ignores siblings
+			return name, nil
+		}
+		names = append(names, name)
+	}
+	if s.Type != nil && !s.Type.HasString() {
+		return noName(), nil
+	}
+	if s.Const.Value != nil {
+		str, ok := (*s.Const.Value).(string)
+		if !ok {
+			return noName(), nil
+		}
+		names = append(names, ast.NewStringName(str))
+	}
+	if s.Enum != nil {
+		enum := []*ast.NameExpr{}
+		for _, v := range s.Enum {
+			if str, ok := v.(string); ok {
+				enum = append(enum, ast.NewStringName(str))
+			}
+		}
+		names = append(names, nameChoice(enum...))
+	}
+	if s.Pattern != nil {
+		names = append(names, ast.NewRegexName(*s.Pattern))
+	}
+	if s.MinLength > 0 || s.MaxLength != nil {
+		name, err := lengthName(s.MinLength, s.MaxLength)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if _, ok := formatExprs[s.Format]; ok {
+		return nil, fmt.Errorf("format %q in propertyNames is not supported", s.Format)
+	}
+	for _, sch := range s.AllOf {
+		name, err := t.translateNameExpr(id, sch, visited)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if len(s.AnyOf) > 0 {
+		anyOf, err := t.translateNameExprs(id, s.AnyOf, visited)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, nameChoice(anyOf...))
+	}
+	if len(s.OneOf) > 0 {
+		oneOf, err := t.translateNameExprs(id, s.OneOf, visited)
+		if err != nil {
+			return nil, err
+		}
+		// exactly one of the names matches, when it matches and none of the others match.
+		alts := make([]*ast.NameExpr, len(oneOf))
+		for i := range oneOf {
+			others, err := t.translateNameExprs(id, s.OneOf, visited)
+			if err != nil {
+				return nil, err
+			}
+			conj := []*ast.NameExpr{oneOf[i]}
+			for j := range others {
+				if i != j {
+					conj = append(conj, ast.NewAnyNameExcept(others[j]))
+				}
+			}
+			alts[i] = nameConj(conj...)
+		}
+		names = append(names, nameChoice(alts...))
+	}
+	if s.Not != nil {
+		name, err := t.translateNameExpr(id, s.Not, visited)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, ast.NewAnyNameExcept(name))
+	}
+	if s.If != nil && (s.Then != nil || s.Else != nil) {
+		name, err := t.translateIfNameExpr(id, s, visited)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return ast.NewAnyName(), nil
+	}
+	return nameConj(names...), nil
+}
+
+func (t *translator) translateNameExprs(parentId string, schs []*schema.Schema, visited map[*schema.Schema]bool) ([]*ast.NameExpr, error) {
+	names := make([]*ast.NameExpr, len(schs))
+	for i, sch := range schs {
+		name, err := t.translateNameExpr(parentId, sch, visited)
+		if err != nil {
+			return nil, err
+		}
+		names[i] = name
+	}
+	return names, nil
+}
+
+// translateIfNameExpr translates if, then and else to (if & then) | (!if & else).
+func (t *translator) translateIfNameExpr(parentId string, s *schema.Schema, visited map[*schema.Schema]bool) (*ast.NameExpr, error) {
+	// the if name expression is used twice, so it is translated twice, instead of being shared.
+	ifs, err := t.translateNameExprs(parentId, []*schema.Schema{s.If, s.If}, visited)
+	if err != nil {
+		return nil, err
+	}
+	thenName := ifs[0]
+	if s.Then != nil {
+		name, err := t.translateNameExpr(parentId, s.Then, visited)
+		if err != nil {
+			return nil, err
+		}
+		thenName = ast.NewNameConj(thenName, name)
+	}
+	elseName := ast.NewAnyNameExcept(ifs[1])
+	if s.Else != nil {
+		name, err := t.translateNameExpr(parentId, s.Else, visited)
+		if err != nil {
+			return nil, err
+		}
+		elseName = ast.NewNameConj(elseName, name)
+	}
+	return ast.NewNameChoice(thenName, elseName), nil
+}
+
+// maxRepeat is the largest repeat count that regular expressions support.
+const maxRepeat = 1000
+
+// maxNameLength is the largest minLength or maxLength of propertyNames, which keeps the regular expression that checks the length small enough to compile.
+const maxNameLength = 64 * maxRepeat
+
+// lengthName returns a name expression that checks that the number of characters in a name is at least minLength and at most maxLength, if maxLength is not nil.
+// Name expressions can only check names with regular expressions, which do not support repeat counts above maxRepeat,
+// so the length is checked with a sequence of repeats, for example a maxLength of 2500 is translated to:
+//
+//	~"^[\s\S]{0,1000}[\s\S]{0,1000}[\s\S]{0,500}$"
+func lengthName(minLength uint64, maxLength *uint64) (*ast.NameExpr, error) {
+	if minLength > maxNameLength || (maxLength != nil && *maxLength > maxNameLength) {
+		return nil, fmt.Errorf("minLength and maxLength in propertyNames above %d are not supported", maxNameLength)
+	}
+	if maxLength != nil && *maxLength < minLength {
+		return noName(), nil
+	}
+	var expr strings.Builder
+	expr.WriteString("^")
+	repeat := func(n uint64, optional bool) {
+		for n > 0 {
+			count := min(n, maxRepeat)
+			expr.WriteString(`[\s\S]{`)
+			if optional {
+				expr.WriteString("0,")
+			}
+			expr.WriteString(strconv.FormatUint(count, 10))
+			expr.WriteString("}")
+			n -= count
+		}
+	}
+	repeat(minLength, false)
+	if maxLength == nil {
+		expr.WriteString(`[\s\S]*`)
+	} else {
+		repeat(*maxLength-minLength, true)
+	}
+	expr.WriteString("$")
+	return ast.NewRegexName(expr.String()), nil
+}
+
+// noName returns a name expression that matches no names.
+func noName() *ast.NameExpr {
+	return ast.NewAnyNameExcept(ast.NewAnyName())
+}
+
+func nameChoice(names ...*ast.NameExpr) *ast.NameExpr {
+	switch len(names) {
+	case 0:
+		return noName()
+	case 1:
+		return names[0]
+	}
+	return ast.NewNameChoice(names...)
+}
+
+func nameConj(names ...*ast.NameExpr) *ast.NameExpr {
+	switch len(names) {
+	case 0:
+		return ast.NewAnyName()
+	case 1:
+		return names[0]
+	}
+	return ast.NewNameConj(names...)
+}
@@ -15,93 +15,81 @@
 package translate
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/katydid/validator-go/validator/ast"
 )
 
-func translateRef(parentId string, name string) (*ast.Pattern, error) {
-	defName, err := refToDefName(parentId, name)
+func (t *translator) translateRef(parentId string, name string) (*ast.Pattern, error) {
+	defName, err := t.refToDefName(parentId, name)
 	if err != nil {
 		return nil, err
 	}
-	return ast.NewReference(defName), nil
+	return ast.NewReference(t.refName(defName)), nil
 }
 
-func refToDefName(parentId string, ref string) (string, error) {
-	if ref == "#" {
-		return "main", nil
-	}
-	if strings.HasPrefix(ref, "#") && !strings.HasPrefix(ref, "#/") {
-		// anchor
-		return ref, nil
-	}
-	paths, err := parsePointer(ref)
+// refToDefName returns the definition name for a reference, which must resolve to a definition.
+func (t *translator) refToDefName(parentId string, ref string) (string, error) {
+	defName, err := resolveRef(parentId, ref)
 	if err != nil {
 		return "", err
 	}
-	if strings.HasPrefix(ref, "#/") {
-		// make sure relative path is pasted on the back of the back with no removal of the last item.
-		parentId += "/"
-		return prependParentId(parentId, paths), nil
+	if _, ok := t.defs[defName]; !ok {
+		return "", fmt.Errorf("could not resolve reference %s", ref)
 	}
-	return prependParentId(parentId, paths), nil
+	return defName, nil
 }
 
-func definitionToPrefix(prefix string, name string, id string) string {
-	return "/definitions/" + name
+// documentOf returns the URI of the loaded document that contains the definition, otherwise it returns the root id.
+func (t *translator) documentOf(name string) string {
+	doc := ""
+	for uri := range t.documents {
+		if len(uri) <= len(doc) {
+			continue
+		}
+		if name == uri || strings.HasPrefix(name, uri+"#") {
+			doc = uri
+		}
+	}
+	if len(doc) == 0 {
+		return t.rootId
+	}
+	return doc
 }
 
-func prependParentId(parentId string, paths []string) string {
-	if parentId == "" {
-		return strings.Join(paths, "/")
-	}
-	parentPaths, err := parsePointer(parentId)
+// resolveRef returns the definition name for a reference, which is the absolute URI that the reference resolves to against the base URI.
+func resolveRef(base string, ref string) (string, error) {
+	uri, err := resolveURI(base, ref)
 	if err != nil {
-		parentPaths = []string{parentId}
-	}
-	i := 0
-	for i < len(parentPaths) && i < len(paths) && parentPaths[i] == paths[i] {
-		i++
-	}
-	if i != 0 {
-		paths = append(parentPaths[:i], paths[i:]...)
-		return strings.Join(paths, "/")
+		return "", err
 	}
-	// remove last slash or last item
-	parentPaths = parentPaths[:len(parentPaths)-1]
-	return strings.Join(append(parentPaths, paths...), "/")
+	return uriToDefName(uri), nil
 }
 
-func definitionToDefName(prefix string, parentId string, name string, id string, anchor string) (string, error) {
-	if len(anchor) > 0 {
-		return "#" + anchor, nil
+// uriToDefName returns the definition name for an absolute URI, where the empty URI is the root schema without an id.
+func uriToDefName(uri string) string {
+	if len(uri) == 0 {
+		return "main"
 	}
-	if len(id) > 0 {
-		if strings.HasPrefix(id, "#") && !strings.HasPrefix(id, "#/") {
-			if len(parentId) > 0 {
-				if !strings.HasSuffix(parentId, "/") {
-					parentId += "/"
-				}
-				return prependParentId(parentId, []string{id[1:]}), nil
-			}
-			return id, nil
-		}
-		paths, err := parsePointer(id)
-		if err != nil {
-			return "", err
-		}
-		return prependParentId(parentId, paths), nil
-	}
-	name = "/definitions/" + name
-	s := prefix + name
-	paths, err := parsePointer(s)
-	if err != nil {
-		return "", err
+	return uri
+}
+
+// refToAnchor returns the anchor name if the reference is to a plain name fragment, for example "#items" or "tree.json#items", otherwise it returns the empty string.
+func refToAnchor(ref string) string {
+	i := strings.Index(ref, "#")
+	if i < 0 {
+		return ""
 	}
-	if len(parentId) > 0 && !strings.HasSuffix(parentId, "/") {
-		// make sure relative path is pasted on the back of the back with no removal of the last item.
-		parentId += "/"
+	fragment := ref[i+1:]
+	if strings.HasPrefix(fragment, "/") {
+		return ""
 	}
-	return prependParentId(parentId, paths), nil
+	return fragment
+}
+
+// dynamicAnchorToDefName returns the definition name for a $dynamicAnchor.
+// Unlike $anchor it includes the id of the schema resource, since extensible schemas declare the same $dynamicAnchor in multiple schema resources.
+func dynamicAnchorToDefName(resourceId string, anchor string) string {
+	return resourceId + "#" + anchor
 }
@@ -14,7 +14,40 @@
 
 package translate
 
-import "testing"
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+// expectRef finds the definitions of the schema and checks that the reference,
+// in a subschema with the given base URI, resolves to the wanted definition name, which it returns the schema of.
+func expectRef(t *testing.T, version schema.Version, input string, base string, ref string, want string) *schema.Schema {
+	t.Helper()
+	s, err := schema.ParseSchema([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetDefaultVersion(version)
+	defs, err := newDefinitionFinder(newOptions(nil)).findDefinitions(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defName, err := resolveRef(base, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if defName != want {
+		t.Fatalf("got %s want %s", defName, want)
+	}
+	def, ok := defs[defName]
+	if !ok {
+		t.Fatalf("%s is not a definition", defName)
+	}
+	return def
+}
 
 // # Draft 4 test case:
 //
@@ -55,22 +88,10 @@ import "testing"
 //		]
 //	},
 func TestDraft4RefPreventsSibling(t *testing.T) {
-	want := "http://localhost:1234/sibling_id/base/foo.json"
-	// prefix, parentId, name, id, anchor
-	defName, err := definitionToDefName("", "http://localhost:1234/sibling_id/base/", "base_foo", "foo.json", "")
-	if err != nil {
-		t.Fatal(err)
-	}
-	// parentId, name
-	refName, err := refToDefName("http://localhost:1234/sibling_id/base/", "foo.json")
-	if err != nil {
-		t.Fatal(err)
-	}
-	if defName != refName {
-		t.Fatalf("definitionToDefName = %s, but refToDefName = %s", defName, refName)
-	}
-	if defName != want {
-		t.Fatalf("got %s want %s", defName, want)
+	input := `{"id":"http://localhost:1234/sibling_id/base/","definitions":{"foo":{"id":"http://localhost:1234/sibling_id/foo.json","type":"string"},"base_foo":{"$comment":"this canonical uri is http://localhost:1234/sibling_id/base/foo.json","id":"foo.json","type":"number"}},"allOf":[{"$comment":"$ref resolves to http://localhost:1234/sibling_id/base/foo.json, not http://localhost:1234/sibling_id/foo.json","id":"http://localhost:1234/sibling_id/","$ref":"foo.json"}]}`
+	def := expectRef(t, schema.VersionDraft4, input, "http://localhost:1234/sibling_id/base/", "foo.json", "http://localhost:1234/sibling_id/base/foo.json")
+	if def.Type == nil || !slices.Equal(*def.Type, schema.Type{schema.TypeNumber}) {
+		t.Fatalf("resolved to the wrong schema: %#v", def)
 	}
 }
 
@@ -105,43 +126,18 @@ func TestDraft4RefPreventsSibling(t *testing.T) {
 //		},
 //	}
 func TestDraft4RecursiveReferences1(t *testing.T) {
-	want := "http://localhost:1234/node"
-	// prefix, parentId, name, id, anchor
-	// is the prefix  "http://localhost:1234/tree" ?
-	defName, err := definitionToDefName("", "http://localhost:1234/tree", "node", "http://localhost:1234/node", "")
-	if err != nil {
-		t.Fatal(err)
-	}
-	// parentId, name
-	refName, err := refToDefName("http://localhost:1234/tree", "node")
-	if err != nil {
-		t.Fatal(err)
-	}
-	if defName != refName {
-		t.Fatalf("definitionToDefName = %s, but refToDefName = %s", defName, refName)
-	}
-	if defName != want {
-		t.Fatalf("got %s want %s", defName, want)
+	input := `{"id":"http://localhost:1234/tree","description":"tree of nodes","type":"object","properties":{"meta":{"type":"string"},"nodes":{"type":"array","items":{"$ref":"node"}}},"required":["meta","nodes"],"definitions":{"node":{"id":"http://localhost:1234/node","description":"node","type":"object","properties":{"value":{"type":"number"},"subtree":{"$ref":"tree"}},"required":["value"]}}}`
+	def := expectRef(t, schema.VersionDraft4, input, "http://localhost:1234/tree", "node", "http://localhost:1234/node")
+	if def.Type == nil || !slices.Equal(*def.Type, schema.Type{schema.TypeObject}) {
+		t.Fatalf("resolved to the wrong schema: %#v", def)
 	}
 }
 
 func TestDraft4RecursiveReferences2(t *testing.T) {
-	want := "http://localhost:1234/tree"
-	// prefix, parentId, name, id, anchor
-	defName, err := definitionToDefName("", "", "", "http://localhost:1234/tree", "")
-	if err != nil {
-		t.Fatal(err)
-	}
-	// parentId, name
-	refName, err := refToDefName("http://localhost:1234/tree", "tree")
-	if err != nil {
-		t.Fatal(err)
-	}
-	if defName != refName {
-		t.Fatalf("definitionToDefName = %s, but refToDefName = %s", defName, refName)
-	}
-	if defName != want {
-		t.Fatalf("got %s want %s", defName, want)
+	input := `{"id":"http://localhost:1234/tree","description":"tree of nodes","type":"object","properties":{"meta":{"type":"string"},"nodes":{"type":"array","items":{"$ref":"node"}}},"required":["meta","nodes"],"definitions":{"node":{"id":"http://localhost:1234/node","description":"node","type":"object","properties":{"value":{"type":"number"},"subtree":{"$ref":"tree"}},"required":["value"]}}}`
+	def := expectRef(t, schema.VersionDraft4, input, "http://localhost:1234/node", "tree", "http://localhost:1234/tree")
+	if def.Type == nil || !slices.Equal(*def.Type, schema.Type{schema.TypeObject}) {
+		t.Fatalf("resolved to the wrong schema: %#v", def)
 	}
 }
 
@@ -198,42 +194,18 @@ func TestDraft4RecursiveReferences2(t *testing.T) {
 //		]
 //	}
 func TestDraft4Id1(t *testing.T) {
-	want := "definitions/id_in_enum"
-	// prefix, parentId, name, id, anchor
-	defName, err := definitionToDefName("", "", "id_in_enum", "", "")
-	if err != nil {
-		t.Fatal(err)
-	}
-	// parentId, name
-	refName, err := refToDefName("", "#/definitions/id_in_enum")
-	if err != nil {
-		t.Fatal(err)
-	}
-	if defName != refName {
-		t.Fatalf("definitionToDefName = %s, but refToDefName = %s", defName, refName)
-	}
-	if defName != want {
-		t.Fatalf("got %s want %s", defName, want)
+	input := `{"definitions":{"id_in_enum":{"enum":[{"id":"https://localhost:1234/my_identifier.json","type":"null"}]},"real_id_in_schema":{"id":"https://localhost:1234/my_identifier.json","type":"string"},"zzz_id_in_const":{"const":{"id":"https://localhost:1234/my_identifier.json","type":"null"}}},"anyOf":[{"$ref":"#/definitions/id_in_enum"},{"$ref":"https://localhost:1234/my_identifier.json"}]}`
+	def := expectRef(t, schema.VersionDraft4, input, "", "#/definitions/id_in_enum", "#/definitions/id_in_enum")
+	if def.Enum == nil {
+		t.Fatalf("resolved to the wrong schema: %#v", def)
 	}
 }
 
 func TestDraft4Id2(t *testing.T) {
-	want := "https://localhost:1234/my_identifier.json"
-	// prefix, parentId, name, id, anchor
-	defName, err := definitionToDefName("", "", "real_id_in_schema", "https://localhost:1234/my_identifier.json", "")
-	if err != nil {
-		t.Fatal(err)
-	}
-	// parentId, name
-	refName, err := refToDefName("", "https://localhost:1234/my_identifier.json")
-	if err != nil {
-		t.Fatal(err)
-	}
-	if defName != refName {
-		t.Fatalf("definitionToDefName = %s, but refToDefName = %s", defName, refName)
-	}
-	if defName != want {
-		t.Fatalf("got %s want %s", defName, want)
+	input := `{"definitions":{"id_in_enum":{"enum":[{"id":"https://localhost:1234/my_identifier.json","type":"null"}]},"real_id_in_schema":{"id":"https://localhost:1234/my_identifier.json","type":"string"},"zzz_id_in_const":{"const":{"id":"https://localhost:1234/my_identifier.json","type":"null"}}},"anyOf":[{"$ref":"#/definitions/id_in_enum"},{"$ref":"https://localhost:1234/my_identifier.json"}]}`
+	def := expectRef(t, schema.VersionDraft4, input, "", "https://localhost:1234/my_identifier.json", "https://localhost:1234/my_identifier.json")
+	if def.Type == nil || !slices.Equal(*def.Type, schema.Type{schema.TypeString}) {
+		t.Fatalf("resolved to the wrong schema: %#v", def)
 	}
 }
 
@@ -268,22 +240,10 @@ func TestDraft4Id2(t *testing.T) {
 //		]
 //	},
 func TestDraft4File(t *testing.T) {
-	want := "file:///folder/file.json/definitions/foo"
-	// prefix, parentId, name, id, anchor
-	defName, err := definitionToDefName("", "file:///folder/file.json", "foo", "", "")
-	if err != nil {
-		t.Fatal(err)
-	}
-	// parentId, name
-	refName, err := refToDefName("file:///folder/file.json", "#/definitions/foo")
-	if err != nil {
-		t.Fatal(err)
-	}
-	if defName != refName {
-		t.Fatalf("definitionToDefName = %s, but refToDefName = %s", defName, refName)
-	}
-	if defName != want {
-		t.Fatalf("got %s want %s", defName, want)
+	input := `{"id":"file:///folder/file.json","definitions":{"foo":{"type":"number"}},"allOf":[{"$ref":"#/definitions/foo"}]}`
+	def := expectRef(t, schema.VersionDraft4, input, "file:///folder/file.json", "#/definitions/foo", "file:///folder/file.json#/definitions/foo")
+	if def.Type == nil || !slices.Equal(*def.Type, schema.Type{schema.TypeNumber}) {
+		t.Fatalf("resolved to the wrong schema: %#v", def)
 	}
 }
 
@@ -322,21 +282,89 @@ func TestDraft4File(t *testing.T) {
 //		]
 //	},
 func TestDraft4LocationIndependent(t *testing.T) {
-	want := "http://localhost:1234/nested.json/foo"
-	// prefix, parentId, name, id, anchor
-	defName, err := definitionToDefName("/definitions/A/definitions/B", "http://localhost:1234/nested.json", "B", "#foo", "")
-	if err != nil {
-		t.Fatal(err)
+	input := `{"id":"http://localhost:1234/root","allOf":[{"$ref":"http://localhost:1234/nested.json#foo"}],"definitions":{"A":{"id":"nested.json","definitions":{"B":{"id":"#foo","type":"integer"}}}}}`
+	def := expectRef(t, schema.VersionDraft4, input, "http://localhost:1234/root", "http://localhost:1234/nested.json#foo", "http://localhost:1234/nested.json#foo")
+	if def.Type == nil || !slices.Equal(*def.Type, schema.Type{schema.TypeInteger}) {
+		t.Fatalf("resolved to the wrong schema: %#v", def)
 	}
-	// parentId, name
-	refName, err := refToDefName("http://localhost:1234/root", "http://localhost:1234/nested.json#foo")
-	if err != nil {
-		t.Fatal(err)
+}
+
+// # Draft 2020-12 test case:
+//
+//	{
+//		"description": "$ref to $defs",
+//		"schema": {
+//			"$schema": "https://json-schema.org/draft/2020-12/schema",
+//			"$defs": {
+//				"a": {"type": "integer"}
+//			},
+//			"$ref": "#/$defs/a"
+//		}
+//	}
+func TestDraft2020Defs(t *testing.T) {
+	input := `{"$schema":"https://json-schema.org/draft/2020-12/schema","$defs":{"a":{"type":"integer"}},"$ref":"#/$defs/a"}`
+	def := expectRef(t, schema.VersionDraft2020, input, "", "#/$defs/a", "#/$defs/a")
+	if def.Type == nil || !slices.Equal(*def.Type, schema.Type{schema.TypeInteger}) {
+		t.Fatalf("resolved to the wrong schema: %#v", def)
 	}
-	if defName != refName {
-		t.Fatalf("definitionToDefName = %s, but refToDefName = %s", defName, refName)
+}
+
+func TestRefPointerIntoKeywords(t *testing.T) {
+	input := `{
+		"$ref": %q,
+		"allOf": [{"type": "object"}, {"properties": {"x": {"type": "integer"}}}],
+		"anyOf": [{"properties": {"x": {"type": "string"}}}],
+		"additionalProperties": {"type": "boolean"},
+		"patternProperties": {"^x-": {"type": "number"}},
+		"not": {"type": "null"},
+		"dependencies": {"foo": {"type": "object"}, "bar": ["foo"]},
+		"dependentSchemas": {"bar": {"type": "array"}},
+		"items": [{"type": "string"}],
+		"additionalItems": false
+	}`
+	expect := func(ref string, want schema.SimpleType) {
+		t.Helper()
+		def := expectRef(t, schema.VersionDraft2019, fmt.Sprintf(input, ref), "", ref, ref)
+		if def.Type == nil || !slices.Equal(*def.Type, schema.Type{want}) {
+			t.Fatalf("%s resolved to the wrong schema: %#v", ref, def)
+		}
 	}
-	if defName != want {
-		t.Fatalf("got %s want %s", defName, want)
+	expect("#/allOf/0", schema.TypeObject)
+	expect("#/allOf/1/properties/x", schema.TypeInteger)
+	expect("#/anyOf/0/properties/x", schema.TypeString)
+	expect("#/additionalProperties", schema.TypeBoolean)
+	expect("#/patternProperties/%5Ex-", schema.TypeNumber)
+	expect("#/not", schema.TypeNull)
+	expect("#/dependencies/foo", schema.TypeObject)
+	expect("#/dependentSchemas/bar", schema.TypeArray)
+	expect("#/items/0", schema.TypeString)
+	def := expectRef(t, schema.VersionDraft2019, fmt.Sprintf(input, "#/additionalItems"), "", "#/additionalItems", "#/additionalItems")
+	if def.Bool == nil || *def.Bool {
+		t.Fatalf("expected the false schema, but got %#v", def)
+	}
+	for _, ref := range []string{"#/allOf/2", "#/dependencies/bar", "#/anyOf/x", "#/unknown/0"} {
+		s, err := schema.ParseSchema([]byte(fmt.Sprintf(input, ref)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defs, err := newDefinitionFinder(newOptions(nil)).findDefinitions(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := defs[ref]; ok {
+			t.Fatalf("expected %s not to resolve", ref)
+		}
+	}
+}
+
+func TestRefMetaSchemas(t *testing.T) {
+	for _, uri := range schema.MetaSchemaURIs() {
+		t.Run(uri, func(t *testing.T) {
+			input := fmt.Sprintf(`{"$schema": "https://json-schema.org/draft/2020-12/schema", "$ref": %q}`, uri+"#")
+			def := expectRef(t, schema.VersionDraft2020, input, "", uri+"#", uri)
+			if got, _ := splitFragment(def.GetId()); got != uri {
+				t.Fatalf("want meta-schema %s, but got %s", uri, def.GetId())
+			}
+		})
 	}
 }
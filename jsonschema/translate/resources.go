@@ -0,0 +1,148 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go/validator/ast"
+)
+
+// Resources are schema documents that are added once by URI and shared between all the schemas that are translated with them.
+// The definitions of a resource that are referenced from another schema are only translated once,
+// which means that the resources must always be translated with the same options, otherwise the translation returns an error.
+type Resources struct {
+	documents map[string]*schema.Schema
+	patterns  map[string]*ast.Pattern
+	// options are the options of the first translation, which change how the definitions are translated.
+	options *resourceOptions
+}
+
+// resourceOptions are the options that change how the definitions of the resources are translated.
+type resourceOptions struct {
+	contentAssertion bool
+	uniqueItems      bool
+	dialects         map[string]*schema.Dialect
+}
+
+func newResourceOptions(o *options) *resourceOptions {
+	return &resourceOptions{
+		contentAssertion: o.contentAssertion,
+		uniqueItems:      o.uniqueItems != nil,
+		dialects:         o.dialects,
+	}
+}
+
+func (o *resourceOptions) equal(other *resourceOptions) bool {
+	return o.contentAssertion == other.contentAssertion &&
+		o.uniqueItems == other.uniqueItems &&
+		maps.Equal(o.dialects, other.dialects)
+}
+
+// checkOptions returns an error if the options are different from the options of the first translation with these resources.
+func (r *Resources) checkOptions(o *options) error {
+	opts := newResourceOptions(o)
+	if r.options == nil {
+		r.options = opts
+		return nil
+	}
+	if !r.options.equal(opts) {
+		return fmt.Errorf("resources must always be translated with the same options")
+	}
+	return nil
+}
+
+func NewResources() *Resources {
+	return &Resources{
+		documents: make(map[string]*schema.Schema),
+		patterns:  make(map[string]*ast.Pattern),
+	}
+}
+
+// Add adds a parsed schema document, which can be referenced by its URI and by its id.
+// If the schema has no id, the URI becomes its id.
+func (r *Resources) Add(uri string, s *schema.Schema) error {
+	docURI, ok := resolveDocumentURI("", uri)
+	if !ok {
+		return fmt.Errorf("resource URI must be absolute: %s", uri)
+	}
+	if len(s.GetId()) == 0 && s.Bool == nil {
+		s.SetId(docURI)
+	}
+	uris := []string{docURI}
+	if id, ok := resolveDocumentURI("", s.GetId()); ok && id != docURI {
+		uris = append(uris, id)
+	}
+	for _, u := range uris {
+		if _, ok := r.documents[u]; ok {
+			return fmt.Errorf("duplicate resource: %s", u)
+		}
+	}
+	for _, u := range uris {
+		r.documents[u] = s
+	}
+	return nil
+}
+
+// Get returns the schema document that was added for the URI.
+func (r *Resources) Get(uri string) (*schema.Schema, bool) {
+	if r == nil {
+		return nil, false
+	}
+	docURI, ok := resolveDocumentURI("", uri)
+	if !ok {
+		return nil, false
+	}
+	s, ok := r.documents[docURI]
+	return s, ok
+}
+
+// cachedDefinition returns a copy of the translated definition if it is part of a shared resource.
+// Definitions are not cached if the schema uses dynamic references, since then their translation depends on the dynamic scope.
+func (t *translator) cachedDefinition(name string) (*ast.Pattern, bool) {
+	if !t.isCacheable(name) {
+		return nil, false
+	}
+	p, ok := t.options.resources.patterns[name]
+	if !ok {
+		return nil, false
+	}
+	return p.Clone(), true
+}
+
+// cacheDefinition stores a copy of the translated definition if it is part of a shared resource.
+func (t *translator) cacheDefinition(name string, p *ast.Pattern) {
+	if !t.isCacheable(name) {
+		return
+	}
+	t.options.resources.patterns[name] = p.Clone()
+}
+
+// isCacheable returns whether the definition is part of a shared resource that was loaded by a reference.
+// The definitions of the root schema are not cached, since a reference to "#" refers to the main definition in the root schema.
+func (t *translator) isCacheable(name string) bool {
+	if t.options.resources == nil || len(t.dynamicAnchors) > 0 {
+		return false
+	}
+	uri := t.documentOf(name)
+	doc, ok := t.documents[uri]
+	if !ok {
+		return false
+	}
+	resource, ok := t.options.resources.documents[uri]
+	return ok && resource == doc
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go/validator/ast"
+)
+
+func TestResourcesAreTranslatedOnce(t *testing.T) {
+	r := NewResources()
+	add := func(uri string, s string) {
+		t.Helper()
+		sch, err := schema.ParseSchema([]byte(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sch.SetDefaultVersion(schema.VersionDraft2020)
+		if err := r.Add(uri, sch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	add("http://example.com/common.json", `{"$defs": {"name": {"type": "string"}}}`)
+	add("http://example.com/a.json", `{"properties": {"name": {"$ref": "http://example.com/common.json#/$defs/name"}}}`)
+	add("http://example.com/b.json", `{"items": {"$ref": "http://example.com/common.json#/$defs/name"}}`)
+	if err := r.Add("http://example.com/a.json", &schema.Schema{}); err == nil {
+		t.Fatalf("expected duplicate resource error")
+	}
+	if err := r.Add("a.json", &schema.Schema{}); err == nil {
+		t.Fatalf("expected relative resource URI error")
+	}
+
+	a, _ := r.Get("http://example.com/a.json")
+	if _, err := NewGrammarFromSchema(a, WithResources(r)); err != nil {
+		t.Fatal(err)
+	}
+	cached := r.patterns["http://example.com/common.json#/$defs/name"]
+	if cached == nil {
+		t.Fatalf("expected the shared definition to be cached, but got %v", r.patterns)
+	}
+	if _, ok := r.patterns["http://example.com/a.json"]; ok {
+		t.Fatalf("the root schema should not be cached")
+	}
+	b, _ := r.Get("http://example.com/b.json#")
+	g, err := NewGrammarFromSchema(b, WithResources(r))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.patterns["http://example.com/common.json#/$defs/name"] != cached {
+		t.Fatalf("expected the shared definition to be translated once")
+	}
+	if _, ok := ast.NewRefLookup(g)["http://example.com/common.json#/$defs/name"]; !ok {
+		t.Fatalf("expected the shared definition in %v", g)
+	}
+}
+
+func TestResourcesRequireTheSameOptions(t *testing.T) {
+	r := NewResources()
+	s, err := schema.ParseSchema([]byte(`{"$defs": {"name": {"type": "string"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetDefaultVersion(schema.VersionDraft2020)
+	if err := r.Add("http://example.com/common.json", s); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewGrammarFromSchema(s, WithResources(r)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewGrammarFromSchema(s, WithResources(r)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewGrammarFromSchema(s, WithResources(r), WithContentAssertion()); err == nil {
+		t.Fatalf("expected an error for resources that are translated with different options")
+	}
+}
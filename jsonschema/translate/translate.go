@@ -19,42 +19,145 @@ import (
 	"github.com/katydid/validator-go/validator/ast"
 )
 
-func Translate(s *schema.Schema) (*ast.Grammar, error) {
-	defs, err := translateDefinitions(s)
+// Translate translates the schema to a grammar.
+// If the grammar contains content functions, then their nested grammars stay registered while the grammar is reachable.
+func Translate(s *schema.Schema, opts ...Option) (*ast.Grammar, error) {
+	t, defs, err := newDefinitions(s, newOptions(opts))
 	if err != nil {
 		return nil, err
 	}
-	return ast.NewGrammar(ast.RefLookup(defs)), nil
+	g := ast.NewGrammar(ast.RefLookup(defs))
+	bindContents(g, t.contentKeys)
+	return g, nil
 }
 
-func translateWithParentId(parentId string) func(s *schema.Schema) (*ast.Pattern, error) {
+// translator holds the state that is shared while translating all the definitions of a schema.
+type translator struct {
+	options *options
+	// defs maps definition names to schemas, which allows references to be followed during translation.
+	defs map[string]*schema.Schema
+	// bases maps definition names to the base URIs of their parents, which is the parentId that each definition is translated with.
+	bases map[string]string
+	// documents maps the URIs of the loaded documents to their schemas.
+	documents map[string]*schema.Schema
+	// rootId is the base URI of the root schema resource.
+	rootId string
+	// resources maps the id of each schema resource to the definition names of its $dynamicAnchors.
+	resources map[string]map[string]string
+	// dynamicAnchors are the anchor names that are referenced by a $dynamicRef.
+	dynamicAnchors map[string]bool
+	// bindings is the dynamic scope, which maps each anchor name to the definition name of the outermost $dynamicAnchor.
+	bindings map[string]string
+	// specializations are the definitions that are translated in a dynamic scope that differs from their default dynamic scope.
+	specializations map[string]*specialization
+	// pending are the names of the specializations that still need to be translated.
+	pending []string
+	// contents maps the keys of the translated contentSchemas to their patterns, until they are registered.
+	contents map[string]*ast.Pattern
+	// contentKeys are the keys of the nested grammars that were registered for the definitions.
+	contentKeys []string
+}
+
+func newTranslator(root *schema.Schema, defs map[string]*schema.Schema, bases map[string]string, documents map[string]*schema.Schema, o *options) *translator {
+	t := &translator{
+		options:         o,
+		defs:            defs,
+		bases:           bases,
+		documents:       documents,
+		rootId:          getId("", root),
+		specializations: make(map[string]*specialization),
+	}
+	t.findDynamicScopes(root)
+	return t
+}
+
+// translateDefinition translates the named definition in the given dynamic scope.
+func (t *translator) translateDefinition(parentId string, defName string, bindings map[string]string) (*ast.Pattern, error) {
+	t.bindings = bindings
+	return t.translate(parentId, t.defs[defName])
+}
+
+func (t *translator) translateWithParentId(parentId string) func(s *schema.Schema) (*ast.Pattern, error) {
 	return func(s *schema.Schema) (*ast.Pattern, error) {
-		return translate(parentId, s)
+		return t.translate(parentId, s)
 	}
 }
 
-func translate(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+func (t *translator) translate(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+	if s.Bool != nil {
+		return translateBool(*s.Bool), nil
+	}
+	if id := s.GetId(); len(id) > 0 {
+		// a subschema with an id starts a new schema resource, which enters the dynamic scope.
+		bindings := t.bindings
+		t.bindings = t.enter(getId(parentId, s))
+		defer func() {
+			t.bindings = bindings
+		}()
+	}
 	if s.Const.Value != nil {
 		// If there is a const no other constraints are necessary.
 		return translateConst(*s.Const.Value)
 	}
 	if s.Default != nil {
-		// default works if we do nothing
+		// default is only an annotation, which is collected by Explainer.Annotate
 	}
-	ptype, err := translateTypeConstraints(parentId, s)
+	ptype, err := t.translateTypeConstraints(parentId, s)
 	if err != nil {
 		return nil, err
 	}
 	ps := []*ast.Pattern{ptype}
+	if t.options.contentAssertion && s.GetVersion() >= schema.VersionDraft7 && s.HasContentConstraints() {
+		p, err := t.translateContent(parentId, s)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
 	if s.HasOperatorConstraints() {
-		p, err := translateOperators(parentId, s)
+		p, err := t.translateOperators(parentId, s)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	if s.GetVersion() == schema.VersionDraft3 && s.HasDraft3Constraints() {
+		p, err := t.translateDraft3(parentId, s)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	if s.UnevaluatedProperties != nil && s.GetVersion() >= schema.VersionDraft2019 {
+		p, err := t.translateUnevaluatedProperties(parentId, s)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	if s.UnevaluatedItems != nil && s.GetVersion() >= schema.VersionDraft2019 {
+		p, err := t.translateUnevaluatedItems(parentId, s)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	if len(s.DynamicRef) > 0 && s.GetVersion() >= schema.VersionDraft2020 {
+		p, err := t.translateDynamicRef(getId(parentId, s), s.DynamicRef)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	if len(s.RecursiveRef) > 0 && s.GetVersion() == schema.VersionDraft2019 {
+		p, err := t.translateRecursiveRef(getId(parentId, s), s.RecursiveRef)
 		if err != nil {
 			return nil, err
 		}
 		ps = append(ps, p)
 	}
 	if len(s.Ref) > 0 {
-		p, err := translateRef(parentId, s.Ref)
+		p, err := t.translateRef(getId(parentId, s), s.Ref)
 		if err != nil {
 			return nil, err
 		}
@@ -67,7 +170,15 @@ func translate(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 	return newAnd(ps...), nil
 }
 
-func translateTypeConstraints(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+// translateBool translates a boolean schema, where true matches anything and false matches nothing.
+func translateBool(b bool) *ast.Pattern {
+	if b {
+		return ast.NewZAny()
+	}
+	return ast.NewNot(ast.NewZAny())
+}
+
+func (t *translator) translateTypeConstraints(parentId string, s *schema.Schema) (*ast.Pattern, error) {
 	var ps []*ast.Pattern
 	if hasType(s.Type, schema.TypeNull) {
 		ps = append(ps, nullType())
@@ -119,7 +230,7 @@ func translateTypeConstraints(parentId string, s *schema.Schema) (*ast.Pattern,
 	}
 	if hasType(s.Type, schema.TypeArray) {
 		if s.HasArrayConstraints() {
-			p, err := translateArray(parentId, s)
+			p, err := t.translateArray(parentId, s)
 			if err != nil {
 				return nil, err
 			}
@@ -130,7 +241,7 @@ func translateTypeConstraints(parentId string, s *schema.Schema) (*ast.Pattern,
 			ps = append(ps, typ)
 		}
 	} else if s.HasArrayConstraints() {
-		p, err := translateArray(parentId, s)
+		p, err := t.translateArray(parentId, s)
 		if err != nil {
 			return nil, err
 		}
@@ -139,7 +250,7 @@ func translateTypeConstraints(parentId string, s *schema.Schema) (*ast.Pattern,
 	}
 	if hasType(s.Type, schema.TypeObject) {
 		if s.HasObjectConstraints() {
-			p, err := translateObject(parentId, s)
+			p, err := t.translateObject(parentId, s)
 			if err != nil {
 				return nil, err
 			}
@@ -150,7 +261,7 @@ func translateTypeConstraints(parentId string, s *schema.Schema) (*ast.Pattern,
 			ps = append(ps, typ)
 		}
 	} else if s.HasObjectConstraints() {
-		p, err := translateObject(parentId, s)
+		p, err := t.translateObject(parentId, s)
 		if err != nil {
 			return nil, err
 		}
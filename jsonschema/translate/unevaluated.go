@@ -0,0 +1,468 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+	"github.com/katydid/validator-go/validator/ast"
+)
+
+// annotations is one alternative of what a schema and its in-place applicators evaluate.
+// The annotations are only collected if all the conditions match the value,
+// for example the annotations of an anyOf branch are only collected if that branch matches.
+type annotations struct {
+	conds []*ast.Pattern
+	// properties are the names of the properties that are evaluated.
+	properties []string
+	// patterns are the regular expressions of the property names that are evaluated.
+	patterns []string
+	// allProperties is set if all properties are evaluated, for example by additionalProperties.
+	allProperties bool
+	// prefix is the number of leading items that are evaluated, for example by prefixItems.
+	prefix int
+	// contains are the patterns of contains, where each item that matches one of them is evaluated.
+	contains []*ast.Pattern
+	// allItems is set if all items are evaluated, for example by items.
+	allItems bool
+}
+
+func (a *annotations) isEmpty() bool {
+	return len(a.properties) == 0 && len(a.patterns) == 0 && !a.allProperties &&
+		a.prefix == 0 && len(a.contains) == 0 && !a.allItems
+}
+
+func (a *annotations) withCond(cond *ast.Pattern) *annotations {
+	res := *a
+	res.conds = append([]*ast.Pattern{cond}, a.conds...)
+	return &res
+}
+
+func mergeAnnotations(a, b *annotations) *annotations {
+	return &annotations{
+		conds:         slices.Concat(a.conds, b.conds),
+		properties:    slices.Concat(a.properties, b.properties),
+		patterns:      slices.Concat(a.patterns, b.patterns),
+		allProperties: a.allProperties || b.allProperties,
+		prefix:        max(a.prefix, b.prefix),
+		contains:      slices.Concat(a.contains, b.contains),
+		allItems:      a.allItems || b.allItems,
+	}
+}
+
+// maxAlternatives is the maximum number of alternatives of what a schema and its in-place applicators evaluate.
+// The alternatives grow exponentially with the number of branches of anyOf and multiply across oneOf, if and dependentSchemas,
+// so more alternatives are not supported, since the grammar would be too big.
+const maxAlternatives = 256
+
+// maxAnyOfBranches is the maximum number of branches of anyOf that evaluate something, since every combination of branches is an alternative.
+const maxAnyOfBranches = 8
+
+// ErrTooManyAlternatives is returned for unevaluatedProperties or unevaluatedItems,
+// if the in-place applicators of the schema have more than maxAlternatives alternatives of what they evaluate.
+var ErrTooManyAlternatives = errors.New("unevaluatedProperties and unevaluatedItems are not supported for schemas with this many alternatives of evaluated properties and items")
+
+func tooManyAlternatives(n int) error {
+	return fmt.Errorf("%w: anyOf, oneOf, if and dependentSchemas have %d alternatives, but at most %d are supported", ErrTooManyAlternatives, n, maxAlternatives)
+}
+
+// productAnnotations returns all the combinations of alternatives, where each alternative from as is merged with each alternative from bs.
+// It returns an error if there are more than maxAlternatives combinations.
+func productAnnotations(as, bs []*annotations) ([]*annotations, error) {
+	if n := len(as) * len(bs); n > maxAlternatives {
+		return nil, tooManyAlternatives(n)
+	}
+	res := make([]*annotations, 0, len(as)*len(bs))
+	for _, a := range as {
+		for _, b := range bs {
+			res = append(res, mergeAnnotations(a, b))
+		}
+	}
+	return res, nil
+}
+
+func allEmpty(as []*annotations) bool {
+	for _, a := range as {
+		if !a.isEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+func withCond(cond *ast.Pattern, as []*annotations) []*annotations {
+	return std.Map(as, func(a *annotations) *annotations {
+		return a.withCond(cond)
+	})
+}
+
+func clonePatterns(ps []*ast.Pattern) []*ast.Pattern {
+	return std.Map(ps, func(p *ast.Pattern) *ast.Pattern {
+		return p.Clone()
+	})
+}
+
+// unevaluatedProperties applies to all properties that were not evaluated by the schema or any of its in-place applicators, for example:
+//
+//	"allOf": [{"properties": {"a": true}}],
+//	"anyOf": [{"properties": {"b": true}}, {"properties": {"c": true}}],
+//	"unevaluatedProperties": false
+//
+// Which properties are evaluated depends on which branches of anyOf, oneOf and if match the value.
+// We calculate all the alternatives of evaluated properties, each with the conditions that need to match:
+//
+//	{"a"} | anyOf[0]&{"a","b"} | anyOf[1]&{"a","c"} | anyOf[0]&anyOf[1]&{"a","b","c"}
+//
+// An alternative with fewer evaluated properties is always stricter than one with more,
+// which means that the disjunction of all alternatives only needs to hold for the alternative with all matching branches.
+// The alternatives are translated to:
+//
+//	unevaluated({"a"}) | (anyOf[0] & unevaluated({"a","b"})) | (anyOf[1] & unevaluated({"a","c"})) | ...
+//
+// where unevaluated checks that the unevaluatedProperties schema matches all properties, except the evaluated ones.
+// Since the number of alternatives grows exponentially with the branches of anyOf, it is limited to maxAlternatives, see ErrTooManyAlternatives.
+func (t *translator) translateUnevaluatedProperties(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+	unevaluated, err := t.translate(getId(parentId, s), s.UnevaluatedProperties)
+	if err != nil {
+		return nil, err
+	}
+	if unevaluated.ZAny != nil {
+		return ast.NewZAny(), nil
+	}
+	alts, err := t.evaluatedAlternatives(parentId, s)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*ast.Pattern, 0, len(alts))
+	for _, alt := range alts {
+		conds := clonePatterns(alt.conds)
+		if alt.allProperties {
+			if len(conds) == 0 {
+				return ast.NewZAny(), nil
+			}
+			res = append(res, newAnd(conds...))
+			continue
+		}
+		check := newOr(NewObjectNode(unevaluatedProperties(alt, unevaluated.Clone())), notObjectType())
+		res = append(res, newAnd(append(conds, check)...))
+	}
+	return newOr(res...), nil
+}
+
+// unevaluatedProperties returns a pattern that matches the contents of an object,
+// where all the properties that are not evaluated need to match the unevaluated pattern.
+func unevaluatedProperties(alt *annotations, unevaluated *ast.Pattern) *ast.Pattern {
+	properties := slices.Compact(slices.Sorted(slices.Values(alt.properties)))
+	patterns := slices.Compact(slices.Sorted(slices.Values(alt.patterns)))
+	nameExprs := func() []*ast.NameExpr {
+		names := make([]*ast.NameExpr, 0, len(properties)+len(patterns))
+		for _, name := range properties {
+			names = append(names, ast.NewStringName(name))
+		}
+		for _, name := range patterns {
+			names = append(names, ast.NewRegexName(name))
+		}
+		return names
+	}
+	if len(properties) == 0 && len(patterns) == 0 {
+		return ast.NewZeroOrMore(ast.NewTreeNode(ast.NewAnyName(), unevaluated))
+	}
+	evaluated := ast.NewTreeNode(ast.NewNameChoice(nameExprs()...), ast.NewZAny())
+	others := ast.NewTreeNode(ast.NewAnyNameExcept(ast.NewNameChoice(nameExprs()...)), unevaluated)
+	return ast.NewZeroOrMore(newOr(evaluated, others))
+}
+
+// unevaluatedItems applies to all items that were not evaluated by the schema or any of its in-place applicators.
+// Items are evaluated by the leading prefixItems, by items, which evaluates the rest of the items, and since Draft 2020-12 by contains.
+// The alternatives of evaluated items are calculated and translated in the same way as for unevaluatedProperties.
+func (t *translator) translateUnevaluatedItems(parentId string, s *schema.Schema) (*ast.Pattern, error) {
+	unevaluated, err := t.translate(getId(parentId, s), s.UnevaluatedItems)
+	if err != nil {
+		return nil, err
+	}
+	if unevaluated.ZAny != nil {
+		return ast.NewZAny(), nil
+	}
+	alts, err := t.evaluatedAlternatives(parentId, s)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*ast.Pattern, 0, len(alts))
+	for _, alt := range alts {
+		conds := clonePatterns(alt.conds)
+		if alt.allItems {
+			if len(conds) == 0 {
+				return ast.NewZAny(), nil
+			}
+			res = append(res, newAnd(conds...))
+			continue
+		}
+		check := newOr(NewArrayNode(unevaluatedItems(alt, unevaluated.Clone())), notArrayType())
+		res = append(res, newAnd(append(conds, check)...))
+	}
+	return newOr(res...), nil
+}
+
+// unevaluatedItems returns a pattern that matches the contents of an array,
+// where all the items after the evaluated prefix, that also do not match any contains pattern, need to match the unevaluated pattern.
+func unevaluatedItems(alt *annotations, unevaluated *ast.Pattern) *ast.Pattern {
+	rest := ast.NewZeroOrMore(anyIndex(newOr(append(clonePatterns(alt.contains), unevaluated)...)))
+	if alt.prefix == 0 {
+		return rest
+	}
+	prefix := make([]*ast.Pattern, 0, alt.prefix+1)
+	for i := 0; i < alt.prefix; i++ {
+		prefix = append(prefix, anyIndex(ast.NewZAny()))
+	}
+	// arrays that are shorter than the prefix have no unevaluated items.
+	return newOr(ast.NewConcat(append(prefix, rest)...), maxItems(alt.prefix-1))
+}
+
+// evaluatedAlternatives returns the alternatives of what the schema and its in-place applicators evaluate.
+func (t *translator) evaluatedAlternatives(parentId string, s *schema.Schema) ([]*annotations, error) {
+	local, err := t.localAnnotations(parentId, s)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := t.applicatorAnnotations(parentId, s, map[*schema.Schema]bool{s: true})
+	if err != nil {
+		return nil, err
+	}
+	return productAnnotations([]*annotations{local}, applied)
+}
+
+// localAnnotations returns what is evaluated by the schema itself, without considering its in-place applicators.
+func (t *translator) localAnnotations(parentId string, s *schema.Schema) (*annotations, error) {
+	a := &annotations{
+		properties:    std.SortedKeys(s.GetProperties()),
+		patterns:      std.SortedKeys(s.PatternProperties),
+		allProperties: s.AdditionalProperties != nil,
+	}
+	if s.GetVersion() >= schema.VersionDraft2020 {
+		a.prefix = len(s.PrefixItems)
+		a.allItems = s.Items.GetObject() != nil
+		if s.Contains != nil {
+			p, err := t.translate(getId(parentId, s), s.Contains)
+			if err != nil {
+				return nil, err
+			}
+			a.contains = []*ast.Pattern{p}
+		}
+	} else {
+		a.prefix = len(s.Items.GetArray())
+		a.allItems = s.Items.GetObject() != nil || (s.Items.GetArray() != nil && s.AdditionalItems != nil)
+	}
+	return a, nil
+}
+
+// subschemaAnnotations returns the alternatives of what a subschema, that is applied in-place, evaluates.
+func (t *translator) subschemaAnnotations(parentId string, s *schema.Schema, visited map[*schema.Schema]bool) ([]*annotations, error) {
+	if s.Bool != nil {
+		return []*annotations{{}}, nil
+	}
+	local, err := t.localAnnotations(parentId, s)
+	if err != nil {
+		return nil, err
+	}
+	if s.UnevaluatedProperties != nil {
+		// nested unevaluatedProperties evaluates all the properties that are left over.
+		local.allProperties = true
+	}
+	if s.UnevaluatedItems != nil {
+		// nested unevaluatedItems evaluates all the items that are left over.
+		local.allItems = true
+	}
+	applied, err := t.applicatorAnnotations(parentId, s, visited)
+	if err != nil {
+		return nil, err
+	}
+	return productAnnotations([]*annotations{local}, applied)
+}
+
+// applicatorAnnotations returns the alternatives of what the in-place applicators of a schema evaluate.
+func (t *translator) applicatorAnnotations(parentId string, s *schema.Schema, visited map[*schema.Schema]bool) ([]*annotations, error) {
+	id := getId(parentId, s)
+	alts := []*annotations{{}}
+	for _, sch := range s.AllOf {
+		// allOf always has to match, so no extra conditions are required.
+		as, err := t.subschemaAnnotations(id, sch, visited)
+		if err != nil {
+			return nil, err
+		}
+		alts, err = productAnnotations(alts, as)
+		if err != nil {
+			return nil, err
+		}
+	}
+	refs := []string{}
+	if len(s.Ref) > 0 {
+		defName, err := t.refToDefName(id, s.Ref)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, defName)
+	}
+	if len(s.DynamicRef) > 0 && s.GetVersion() >= schema.VersionDraft2020 {
+		defName, err := t.dynamicRefToDefName(id, s.DynamicRef)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, defName)
+	}
+	if len(s.RecursiveRef) > 0 && s.GetVersion() == schema.VersionDraft2019 {
+		defName, err := t.recursiveRefToDefName(id, s.RecursiveRef)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, defName)
+	}
+	for _, defName := range refs {
+		ref := t.defs[defName]
+		if ref != nil && !visited[ref] {
+			visited[ref] = true
+			as, err := t.subschemaAnnotations(t.bases[defName], ref, visited)
+			delete(visited, ref)
+			if err != nil {
+				return nil, err
+			}
+			alts, err = productAnnotations(alts, as)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(s.AnyOf) > 0 {
+		branches, err := t.branchAnnotations(id, s.AnyOf, visited)
+		if err != nil {
+			return nil, err
+		}
+		if len(branches) > maxAnyOfBranches {
+			return nil, fmt.Errorf("%w: anyOf has %d branches that evaluate properties or items, but at most %d are supported", ErrTooManyAlternatives, len(branches), maxAnyOfBranches)
+		}
+		// Any combination of branches can match.
+		anyOf := []*annotations{{}}
+		for _, subset := range std.Subsets(branches) {
+			combo := []*annotations{{}}
+			for _, branch := range subset {
+				combo, err = productAnnotations(combo, branch)
+				if err != nil {
+					return nil, err
+				}
+			}
+			anyOf = append(anyOf, combo...)
+		}
+		alts, err = productAnnotations(alts, anyOf)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(s.OneOf) > 0 {
+		branches, err := t.branchAnnotations(id, s.OneOf, visited)
+		if err != nil {
+			return nil, err
+		}
+		// Only one branch can match.
+		oneOf := []*annotations{{}}
+		for _, branch := range branches {
+			oneOf = append(oneOf, branch...)
+		}
+		alts, err = productAnnotations(alts, oneOf)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if s.If != nil {
+		as, err := t.ifAnnotations(id, s, visited)
+		if err != nil {
+			return nil, err
+		}
+		alts, err = productAnnotations(alts, as)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range std.SortedKeys(dependentSchemasOf(s)) {
+		as, err := t.subschemaAnnotations(id, dependentSchemasOf(s)[name], visited)
+		if err != nil {
+			return nil, err
+		}
+		if allEmpty(as) {
+			continue
+		}
+		hasName := NewObjectNode(ast.NewContains(ast.NewTreeNode(ast.NewStringName(name), ast.NewZAny())))
+		alts, err = productAnnotations(alts, append([]*annotations{{}}, withCond(hasName, as)...))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return alts, nil
+}
+
+// branchAnnotations returns the alternatives of each branch that evaluates something, conditional on that branch matching.
+func (t *translator) branchAnnotations(parentId string, schs []*schema.Schema, visited map[*schema.Schema]bool) ([][]*annotations, error) {
+	var branches [][]*annotations
+	for _, sch := range schs {
+		as, err := t.subschemaAnnotations(parentId, sch, visited)
+		if err != nil {
+			return nil, err
+		}
+		if allEmpty(as) {
+			continue
+		}
+		p, err := t.translate(parentId, sch)
+		if err != nil {
+			return nil, err
+		}
+		branches = append(branches, withCond(p, as))
+	}
+	return branches, nil
+}
+
+// ifAnnotations returns the annotations of if and then, if the condition matches, otherwise the annotations of else.
+func (t *translator) ifAnnotations(parentId string, s *schema.Schema, visited map[*schema.Schema]bool) ([]*annotations, error) {
+	thenAlts, err := t.subschemaAnnotations(parentId, s.If, visited)
+	if err != nil {
+		return nil, err
+	}
+	if s.Then != nil {
+		as, err := t.subschemaAnnotations(parentId, s.Then, visited)
+		if err != nil {
+			return nil, err
+		}
+		thenAlts, err = productAnnotations(thenAlts, as)
+		if err != nil {
+			return nil, err
+		}
+	}
+	elseAlts := []*annotations{{}}
+	if s.Else != nil {
+		elseAlts, err = t.subschemaAnnotations(parentId, s.Else, visited)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if allEmpty(thenAlts) && allEmpty(elseAlts) {
+		return []*annotations{{}}, nil
+	}
+	cond, err := t.translate(parentId, s.If)
+	if err != nil {
+		return nil, err
+	}
+	return append(withCond(cond, thenAlts), withCond(ast.NewNot(cond.Clone()), elseAlts)...), nil
+}
@@ -0,0 +1,416 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	encjson "encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+)
+
+// UniqueItems validates uniqueItems in a pass after the automaton, since uniqueItems cannot be expressed in the automaton.
+// See decisions/uniqueItems.md for why.
+//
+// The pass walks the instance together with the schema, following only the applicators that always apply to a value:
+// properties, patternProperties, additionalProperties, items, prefixItems, additionalItems, allOf, dependentSchemas and $ref.
+// The items of each array that is reached by a schema with uniqueItems are canonicalised and compared.
+// Whether a subschema of anyOf, oneOf, not, if, then, else, contains, propertyNames, unevaluatedItems, unevaluatedProperties,
+// $dynamicRef or $recursiveRef applies depends on the result of the automaton,
+// so uniqueItems is not supported in these subschemas and returns an error when the schema is translated.
+//
+// UniqueItems is initialised when the schema is translated and after that it is safe for concurrent use.
+type UniqueItems struct {
+	root     *schema.Schema
+	defs     map[string]*schema.Schema
+	bases    map[string]string
+	patterns map[string]*regexp.Regexp
+	found    bool
+}
+
+// NewUniqueItems returns a UniqueItems that is initialised by translating a schema with the WithUniqueItems option.
+func NewUniqueItems() *UniqueItems {
+	return &UniqueItems{}
+}
+
+// HasUniqueItems returns true if the translated schema contains uniqueItems, otherwise Validate always returns true.
+func (u *UniqueItems) HasUniqueItems() bool {
+	return u.found
+}
+
+// init finds all the uniqueItems that can be reached from the root and checks that they always apply.
+func (u *UniqueItems) init(root *schema.Schema, defs map[string]*schema.Schema, bases map[string]string) error {
+	u.root = root
+	u.defs = defs
+	u.bases = bases
+	u.patterns = make(map[string]*regexp.Regexp)
+	u.found = false
+	return u.check("", root, "", make(map[uniqueItemsVisit]bool))
+}
+
+type uniqueItemsVisit struct {
+	schema      *schema.Schema
+	conditional bool
+}
+
+// check walks all the subschemas of s.
+// conditional is the keyword of the closest subschema that does not always apply, or empty if s always applies.
+func (u *UniqueItems) check(parentId string, s *schema.Schema, conditional string, visited map[uniqueItemsVisit]bool) error {
+	if s == nil || s.Bool != nil {
+		return nil
+	}
+	visit := uniqueItemsVisit{s, len(conditional) > 0}
+	if visited[visit] {
+		return nil
+	}
+	visited[visit] = true
+	if s.UniqueItems {
+		if len(conditional) > 0 {
+			return fmt.Errorf("uniqueItems is only supported where it always applies, but it is inside %s", conditional)
+		}
+		u.found = true
+	}
+	id := getId(parentId, s)
+	always := func(child *schema.Schema) error {
+		return u.check(id, child, conditional, visited)
+	}
+	sometimes := func(keyword string, child *schema.Schema) error {
+		if len(conditional) > 0 {
+			keyword = conditional
+		}
+		return u.check(id, child, keyword, visited)
+	}
+	if len(s.Ref) > 0 {
+		defName, err := resolveRef(id, s.Ref)
+		if err != nil {
+			return err
+		}
+		if def, ok := u.defs[defName]; ok {
+			if err := u.check(u.bases[defName], def, conditional, visited); err != nil {
+				return err
+			}
+		}
+		if s.GetVersion() <= schema.VersionDraft7 {
+			// before draft version 7 ref silently ignores siblings
+			return nil
+		}
+	}
+	if len(s.DynamicRef) > 0 && s.GetVersion() >= schema.VersionDraft2020 {
+		if err := u.checkDynamic("$dynamicRef", conditional, func(def *schema.Schema) bool {
+			return len(def.DynamicAnchor) > 0
+		}, visited); err != nil {
+			return err
+		}
+	}
+	if len(s.RecursiveRef) > 0 && s.GetVersion() == schema.VersionDraft2019 {
+		if err := u.checkDynamic("$recursiveRef", conditional, func(def *schema.Schema) bool {
+			return def.RecursiveAnchor
+		}, visited); err != nil {
+			return err
+		}
+	}
+	for _, name := range std.SortedKeys(s.Object.GetProperties()) {
+		if err := always(s.Object.GetProperties()[name]); err != nil {
+			return err
+		}
+	}
+	for _, pattern := range std.SortedKeys(s.PatternProperties) {
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+		u.patterns[pattern] = r
+		if err := always(s.PatternProperties[pattern]); err != nil {
+			return err
+		}
+	}
+	if err := always(s.GetAdditionalProperties().GetSchema()); err != nil {
+		return err
+	}
+	if err := always(s.Items.GetObject()); err != nil {
+		return err
+	}
+	for _, child := range s.Items.GetArray() {
+		if err := always(child); err != nil {
+			return err
+		}
+	}
+	for _, child := range s.PrefixItems {
+		if err := always(child); err != nil {
+			return err
+		}
+	}
+	if err := always(s.GetAdditionalItems().GetSchema()); err != nil {
+		return err
+	}
+	for _, child := range s.AllOf {
+		if err := always(child); err != nil {
+			return err
+		}
+	}
+	for _, child := range extendsOf(s) {
+		if err := always(child); err != nil {
+			return err
+		}
+	}
+	for _, name := range std.SortedKeys(dependentSchemasOf(s)) {
+		if err := always(s.DependentSchemas[name]); err != nil {
+			return err
+		}
+	}
+	if s.Dependencies != nil {
+		for _, name := range std.SortedKeys(*s.Dependencies) {
+			if err := always((*s.Dependencies)[name].Schema); err != nil {
+				return err
+			}
+		}
+	}
+	for _, child := range s.AnyOf {
+		if err := sometimes("anyOf", child); err != nil {
+			return err
+		}
+	}
+	for _, child := range s.OneOf {
+		if err := sometimes("oneOf", child); err != nil {
+			return err
+		}
+	}
+	conditionals := []struct {
+		keyword string
+		schema  *schema.Schema
+	}{
+		{"not", s.Not},
+		{"if", s.If},
+		{"then", s.Then},
+		{"else", s.Else},
+		{"contains", s.Contains},
+		{"propertyNames", s.PropertyNames},
+		{"unevaluatedItems", s.UnevaluatedItems},
+		{"unevaluatedProperties", s.UnevaluatedProperties},
+		{"contentSchema", s.ContentSchema},
+	}
+	for _, c := range conditionals {
+		if err := sometimes(c.keyword, c.schema); err != nil {
+			return err
+		}
+	}
+	if s.Disallow != nil {
+		for _, child := range s.Disallow.Schemas {
+			if err := sometimes("disallow", child); err != nil {
+				return err
+			}
+		}
+	}
+	if s.TypeUnion != nil {
+		for _, child := range s.TypeUnion.Schemas {
+			if err := sometimes("type", child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkDynamic checks all the definitions that a dynamic reference could resolve to, since these depend on the dynamic scope.
+func (u *UniqueItems) checkDynamic(keyword string, conditional string, anchored func(def *schema.Schema) bool, visited map[uniqueItemsVisit]bool) error {
+	if len(conditional) > 0 {
+		keyword = conditional
+	}
+	for _, defName := range std.SortedKeys(u.defs) {
+		if def := u.defs[defName]; def.Bool == nil && anchored(def) {
+			if err := u.check(u.bases[defName], def, keyword, visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Validate returns false if an array that is reached by a schema with uniqueItems has duplicate items.
+// It assumes that the instance is valid JSON that has already been validated by the automaton.
+func (u *UniqueItems) Validate(instance []byte) (bool, error) {
+	valid, _, err := u.Locate(instance)
+	return valid, err
+}
+
+// Locate is like Validate, but if the instance is not valid, then it also returns the path to the first array with duplicate items,
+// where each element of the path is either a property name or an array index.
+func (u *UniqueItems) Locate(instance []byte) (bool, []any, error) {
+	if !u.found {
+		return true, nil, nil
+	}
+	var value any
+	if err := std.UnmarshalJSON(instance, &value); err != nil {
+		return false, nil, err
+	}
+	return u.validate("", u.root, value, make(map[*schema.Schema]bool))
+}
+
+// validate validates uniqueItems for all the schemas that apply to the value.
+// visited contains the schemas that already applied to this value, which stops references that loop without consuming any of the value.
+// If the value is not valid, then the path to the array with duplicate items is returned, which is only built when a nested value is not valid.
+func (u *UniqueItems) validate(parentId string, s *schema.Schema, value any, visited map[*schema.Schema]bool) (bool, []any, error) {
+	if s == nil || s.Bool != nil || visited[s] {
+		return true, nil, nil
+	}
+	visited[s] = true
+	id := getId(parentId, s)
+	if len(s.Ref) > 0 {
+		defName, err := resolveRef(id, s.Ref)
+		if err != nil {
+			return false, nil, err
+		}
+		if ok, path, err := u.validate(u.bases[defName], u.defs[defName], value, visited); !ok || err != nil {
+			return ok, path, err
+		}
+		if s.GetVersion() <= schema.VersionDraft7 {
+			// before draft version 7 ref silently ignores siblings
+			return true, nil, nil
+		}
+	}
+	for _, child := range slices.Concat(s.AllOf, extendsOf(s)) {
+		if ok, path, err := u.validate(id, child, value, visited); !ok || err != nil {
+			return ok, path, err
+		}
+	}
+	switch v := value.(type) {
+	case []any:
+		if s.UniqueItems && !uniqueItems(v) {
+			return false, nil, nil
+		}
+		for i, item := range v {
+			for _, child := range u.itemSchemas(s, i) {
+				if ok, path, err := u.validate(id, child, item, make(map[*schema.Schema]bool)); !ok || err != nil {
+					return ok, append([]any{i}, path...), err
+				}
+			}
+		}
+	case map[string]any:
+		for _, name := range std.SortedKeys(v) {
+			for _, child := range u.propertySchemas(s, name) {
+				if ok, path, err := u.validate(id, child, v[name], make(map[*schema.Schema]bool)); !ok || err != nil {
+					return ok, append([]any{name}, path...), err
+				}
+			}
+		}
+		for _, child := range dependentSchemas(s, v) {
+			if ok, path, err := u.validate(id, child, value, visited); !ok || err != nil {
+				return ok, path, err
+			}
+		}
+	}
+	return true, nil, nil
+}
+
+// itemSchemas returns the schemas that apply to the item at the index.
+func (u *UniqueItems) itemSchemas(s *schema.Schema, index int) []*schema.Schema {
+	if s.GetVersion() >= schema.VersionDraft2020 {
+		if index < len(s.PrefixItems) {
+			return []*schema.Schema{s.PrefixItems[index]}
+		}
+		return []*schema.Schema{s.Items.GetObject()}
+	}
+	if s.Items.GetObject() != nil {
+		return []*schema.Schema{s.Items.GetObject()}
+	}
+	if s.Items.GetArray() == nil {
+		return nil
+	}
+	if index < len(s.Items.GetArray()) {
+		return []*schema.Schema{s.Items.GetArray()[index]}
+	}
+	return []*schema.Schema{s.GetAdditionalItems().GetSchema()}
+}
+
+// propertySchemas returns the schemas that apply to the property with the name.
+func (u *UniqueItems) propertySchemas(s *schema.Schema, name string) []*schema.Schema {
+	var schs []*schema.Schema
+	if child, ok := s.Object.GetProperties()[name]; ok {
+		schs = append(schs, child)
+	}
+	for _, pattern := range std.SortedKeys(s.PatternProperties) {
+		if u.patterns[pattern].MatchString(name) {
+			schs = append(schs, s.PatternProperties[pattern])
+		}
+	}
+	if len(schs) == 0 {
+		schs = append(schs, s.GetAdditionalProperties().GetSchema())
+	}
+	return schs
+}
+
+// dependentSchemas returns the schemas of dependentSchemas and dependencies that apply, because their property is present.
+func dependentSchemas(s *schema.Schema, value map[string]any) []*schema.Schema {
+	var schs []*schema.Schema
+	for _, name := range std.SortedKeys(dependentSchemasOf(s)) {
+		if _, ok := value[name]; ok {
+			schs = append(schs, s.DependentSchemas[name])
+		}
+	}
+	if s.Dependencies != nil {
+		for _, name := range std.SortedKeys(*s.Dependencies) {
+			if _, ok := value[name]; ok {
+				schs = append(schs, (*s.Dependencies)[name].Schema)
+			}
+		}
+	}
+	return schs
+}
+
+// uniqueItems returns true if none of the items are equal.
+func uniqueItems(items []any) bool {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		key := canonical(item)
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+	}
+	return true
+}
+
+// canonical returns a string that is equal for equal JSON values.
+// Numbers are equal if they are mathematically equal, for example 1, 1.0 and 1e0, and objects are equal regardless of the order of their keys.
+func canonical(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case encjson.Number:
+		r, ok := new(big.Rat).SetString(string(v))
+		if !ok {
+			return string(v)
+		}
+		return r.RatString()
+	case string:
+		return strconv.Quote(v)
+	case []any:
+		return "[" + strings.Join(std.Map(v, canonical), ",") + "]"
+	case map[string]any:
+		fields := std.Map(std.SortedKeys(v), func(name string) string {
+			return strconv.Quote(name) + ":" + canonical(v[name])
+		})
+		return "{" + strings.Join(fields, ",") + "}"
+	}
+	panic(fmt.Sprintf("unexpected JSON value %#v", value))
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+// Definitions are named after the absolute URIs that identify them, as specified by RFC 3986.
+// The base URI of a schema is the id of the schema resolved against the base URI of its parent,
+// and every reference is resolved against the base URI of the schema that contains it.
+// A root schema without an id has no base URI, which means that the names of its definitions stay relative, for example "#/$defs/a".
+
+// noBase is the base URI that a relative URI is resolved against, when there is no absolute base URI.
+// It is removed again from the resolved URI.
+const noBase = "katydid:///"
+
+// resolveURI resolves the reference against the base URI.
+// The fragment is kept as is and an empty fragment is removed, since "a.json#" and "a.json" identify the same schema.
+func resolveURI(base string, ref string) (string, error) {
+	ref, fragment := splitFragment(ref)
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	if !b.IsAbs() {
+		nb, err := url.Parse(noBase)
+		if err != nil {
+			return "", err
+		}
+		b = nb.ResolveReference(b)
+	}
+	b.Fragment = ""
+	b.RawFragment = ""
+	uri := strings.TrimPrefix(b.ResolveReference(r).String(), noBase)
+	if len(fragment) > 0 {
+		uri += "#" + fragment
+	}
+	return uri, nil
+}
+
+// splitFragment splits the URI into the URI without the fragment and the fragment.
+func splitFragment(uri string) (string, string) {
+	if i := strings.Index(uri, "#"); i >= 0 {
+		return uri[:i], uri[i+1:]
+	}
+	return uri, ""
+}
+
+func isAbsoluteURI(ref string) bool {
+	u, err := url.Parse(ref)
+	return err == nil && u.IsAbs()
+}
+
+// resolveDocumentURI returns the absolute URI of the document that the reference refers to, without the fragment.
+// It returns false if the URI is not absolute.
+func resolveDocumentURI(base string, ref string) (string, bool) {
+	uri, err := resolveURI(base, ref)
+	if err != nil || !isAbsoluteURI(uri) {
+		return "", false
+	}
+	doc, _ := splitFragment(uri)
+	return doc, true
+}
+
+// getId returns the base URI of the schema, which is its id resolved against the base URI of its parent.
+// Before Draft 2019-09 $ref ignores its siblings, including the id.
+func getId(parentId string, s *schema.Schema) string {
+	id := s.GetId()
+	if len(id) == 0 || (len(s.Ref) > 0 && s.GetVersion() <= schema.VersionDraft7) {
+		return parentId
+	}
+	uri, err := resolveURI(parentId, id)
+	if err != nil {
+		return parentId
+	}
+	// before Draft 2019-09 the id can contain a plain name fragment, which is an anchor.
+	base, _ := splitFragment(uri)
+	return base
+}
+
+// getAnchor returns the plain name fragment that identifies the schema within its schema resource.
+// Since Draft 2019-09 this is $anchor and before that it was the fragment of the id, for example "#foo".
+func getAnchor(s *schema.Schema) string {
+	if s.GetVersion() >= schema.VersionDraft2019 {
+		return s.Anchor
+	}
+	if len(s.Ref) > 0 {
+		return ""
+	}
+	_, fragment := splitFragment(s.GetId())
+	if strings.HasPrefix(fragment, "/") {
+		return ""
+	}
+	return fragment
+}
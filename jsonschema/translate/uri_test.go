@@ -0,0 +1,67 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+func TestResolveURI(t *testing.T) {
+	expect := func(base string, ref string, want string) {
+		t.Helper()
+		got, err := resolveURI(base, ref)
+		if err != nil {
+			t.Errorf("given base %s and ref %s error: %v", base, ref, err)
+		} else if got != want {
+			t.Errorf("given base %s and ref %s got %s want %s", base, ref, got, want)
+		}
+	}
+	expect("", "#", "")
+	expect("", "#/$defs/a", "#/$defs/a")
+	expect("", "#foo", "#foo")
+	expect("", "node", "node")
+	expect("a/b.json", "../c.json", "c.json")
+	expect("http://json-schema.org/draft-04/schema", "#", "http://json-schema.org/draft-04/schema")
+	expect("http://json-schema.org/draft-04/schema#", "#/definitions/positiveInteger", "http://json-schema.org/draft-04/schema#/definitions/positiveInteger")
+	expect("http://localhost:1234/tree", "node", "http://localhost:1234/node")
+	expect("http://localhost:1234/sibling_id/base/", "foo.json", "http://localhost:1234/sibling_id/base/foo.json")
+	expect("http://x/a/b.json", "../common/x.json#/$defs/y", "http://x/common/x.json#/$defs/y")
+	expect("http://localhost:1234/root", "http://localhost:1234/nested.json#foo", "http://localhost:1234/nested.json#foo")
+	expect("urn:uuid:deadbeef-1234-ffff-ffff-4321feebdaed", "#/$defs/bar", "urn:uuid:deadbeef-1234-ffff-ffff-4321feebdaed#/$defs/bar")
+	expect("file:///folder/file.json", "#/definitions/foo", "file:///folder/file.json#/definitions/foo")
+	expect("http://localhost:1234/", "#/definitions/percent%25field", "http://localhost:1234/#/definitions/percent%25field")
+}
+
+func TestGetId(t *testing.T) {
+	expect := func(version schema.Version, parentId string, input string, want string) {
+		t.Helper()
+		s, err := schema.ParseSchema([]byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.SetDefaultVersion(version)
+		if got := getId(parentId, s); got != want {
+			t.Errorf("given parent %s and schema %s got %s want %s", parentId, input, got, want)
+		}
+	}
+	expect(schema.VersionDraft2020, "", `{}`, "")
+	expect(schema.VersionDraft2020, "http://x/a/", `{"$id": "b.json"}`, "http://x/a/b.json")
+	expect(schema.VersionDraft2020, "http://x/a/", `{"$id": "b.json#"}`, "http://x/a/b.json")
+	expect(schema.VersionDraft2020, "http://x/a/", `{"$id": "b.json", "$ref": "c.json"}`, "http://x/a/b.json")
+	expect(schema.VersionDraft4, "http://x/a/", `{"id": "b.json", "$ref": "c.json"}`, "http://x/a/")
+	expect(schema.VersionDraft4, "http://x/a.json", `{"id": "#foo"}`, "http://x/a.json")
+}
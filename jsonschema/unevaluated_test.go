@@ -0,0 +1,158 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/translate"
+)
+
+func TestUnevaluatedProperties(t *testing.T) {
+	schemaStr := `
+    {
+      "allOf": [
+        {"properties": {"a": {"type": "string"}}}
+      ],
+      "anyOf": [
+        {"properties": {"b": {"type": "number"}}, "required": ["b"]},
+        {"properties": {"c": {"type": "number"}}, "required": ["c"]}
+      ],
+      "if": {"properties": {"kind": {"const": "d"}}, "required": ["kind"]},
+      "then": {"properties": {"d": true}},
+      "unevaluatedProperties": false
+    }`
+	tests := map[string]bool{
+		`{"b": 1}`:                                 true,
+		`{"a": "x", "b": 1}`:                       true,
+		`{"a": "x", "c": 1}`:                       true,
+		`{"b": 1, "c": 2}`:                         true,
+		`{"b": 1, "e": 2}`:                         false,
+		`{"b": 1, "c": "two"}`:                     false,
+		`{"b": 1, "kind": "d", "d": 3}`:            true,
+		`{"b": 1, "kind": "e", "d": 3}`:            false,
+		`{"b": 1, "kind": "e"}`:                    false,
+		`"notanobject"`:                            true,
+		`{"a": "x", "b": 1, "kind": "d", "d": {}}`: true,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestUnevaluatedPropertiesRef(t *testing.T) {
+	schemaStr := `
+    {
+      "$defs": {
+        "base": {"properties": {"a": true}}
+      },
+      "$ref": "#/$defs/base",
+      "properties": {"b": true},
+      "unevaluatedProperties": {"type": "number"}
+    }`
+	tests := map[string]bool{
+		`{"a": "x", "b": "y"}`:          true,
+		`{"a": "x", "c": 1}`:            true,
+		`{"a": "x", "c": "notanumber"}`: false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestUnevaluatedItems(t *testing.T) {
+	schemaStr := `
+    {
+      "$defs": {
+        "header": {"prefixItems": [{"type": "string"}]}
+      },
+      "$ref": "#/$defs/header",
+      "anyOf": [
+        {"prefixItems": [true, {"type": "number"}]},
+        {"contains": {"type": "boolean"}}
+      ],
+      "unevaluatedItems": false
+    }`
+	tests := map[string]bool{
+		`[]`:                      true,
+		`["a"]`:                   true,
+		`["a", 1]`:                true,
+		`["a", 1, 2]`:             false,
+		`["a", 1, true, false]`:   true,
+		`["a", true, null]`:       false,
+		`[1]`:                     false,
+		`{"notanarray": [1,2,3]}`: true,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestUnevaluatedTooManyAlternatives(t *testing.T) {
+	branches := func(n int) string {
+		bs := make([]string, n)
+		for i := range bs {
+			bs[i] = fmt.Sprintf(`{"properties": {"p%d": true}, "required": ["p%d"]}`, i, i)
+		}
+		return strings.Join(bs, ", ")
+	}
+	supported := `{"anyOf": [` + branches(8) + `], "unevaluatedProperties": false}`
+	got, err := MatchBytes([]byte(supported), []byte(`{"p0": 1, "p7": 2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got {
+		t.Fatal("expected match")
+	}
+	tooMany := map[string]string{
+		"anyOf": `{"anyOf": [` + branches(12) + `], "unevaluatedProperties": false}`,
+		"oneOf": `{"allOf": [{"oneOf": [` + branches(20) + `]}, {"oneOf": [` + branches(20) + `]}], "unevaluatedItems": false}`,
+	}
+	for name, schemaStr := range tooMany {
+		t.Run(name, func(t *testing.T) {
+			_, err := MatchBytes([]byte(schemaStr), []byte(`{}`))
+			if !errors.Is(err, translate.ErrTooManyAlternatives) {
+				t.Fatalf("want ErrTooManyAlternatives, but got %v", err)
+			}
+		})
+	}
+}
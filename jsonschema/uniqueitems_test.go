@@ -0,0 +1,243 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json"
+)
+
+func TestUniqueItems(t *testing.T) {
+	schema := `{"uniqueItems": true}`
+	tests := map[string]bool{
+		`[]`:                                   true,
+		`[1, 2, 3]`:                            true,
+		`[1, 2, 1]`:                            false,
+		`[1, 1.0]`:                             false,
+		`[1, 1e0, 10e-1]`:                      false,
+		`[1, 1.5]`:                             true,
+		`[0, false, null, "0", [], {}]`:        true,
+		`["a", "a"]`:                           false,
+		`[["foo"], ["bar"], ["foo"]]`:          false,
+		`[["foo", "bar"], ["bar", "foo"]]`:     true,
+		`[{"a": 1, "b": 2}, {"b": 2, "a": 1}]`: false,
+		`[{"a": 1, "b": 2}, {"a": 1, "b": 3}]`: true,
+		`[{"a": [1]}, {"a": [1.0]}]`:           false,
+		`"not an array"`:                       true,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			for name, newMatcher := range map[string]func([]byte, ...Option) (Matcher, error){
+				"interpreter": NewInterpreter,
+				"memoizer":    NewMemoizer,
+				"compiled":    Compile,
+			} {
+				m, err := newMatcher([]byte(schema), WithUniqueItems())
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := m.MatchBytes([]byte(test))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("%s: want %v, but got %v", name, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestUniqueItemsInSubschemas(t *testing.T) {
+	schema := `
+    {
+      "$schema": "https://json-schema.org/draft/2020-12/schema",
+      "type": "object",
+      "properties": {
+        "tags": { "$ref": "#/$defs/tags" },
+        "matrix": { "items": { "uniqueItems": true } }
+      },
+      "patternProperties": {
+        "^x-": { "uniqueItems": true }
+      },
+      "additionalProperties": false,
+      "$defs": {
+        "tags": {
+          "type": "array",
+          "items": { "type": "string" },
+          "uniqueItems": true
+        }
+      }
+    }`
+	tests := map[string]bool{
+		`{"tags": ["a", "b"]}`:             true,
+		`{"tags": ["a", "b", "a"]}`:        false,
+		`{"tags": [1, 1]}`:                 false,
+		`{"matrix": [[1, 2], [1, 2]]}`:     true,
+		`{"matrix": [[1, 2], [2, 2]]}`:     false,
+		`{"x-ids": [1, 2]}`:                true,
+		`{"x-ids": [1, 2, 2]}`:             false,
+		`{"tags": ["a"], "x-ids": [3, 3]}`: false,
+		`{"other": [1, 1]}`:                false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schema), []byte(test), WithUniqueItems())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, but got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestUniqueItemsItemsBeforeDraft2020(t *testing.T) {
+	schema := `
+    {
+      "$schema": "http://json-schema.org/draft-07/schema#",
+      "items": [{ "uniqueItems": true }],
+      "additionalItems": { "items": { "uniqueItems": true } }
+    }`
+	tests := map[string]bool{
+		`[[1, 2], [[1], [2]]]`:           true,
+		`[[1, 1]]`:                       false,
+		`[[1, 2], [[1], [2, 2]]]`:        false,
+		`[[1, 2], [[1], [2]], [[3]]]`:    true,
+		`[[1, 2], [[1], [2]], [[3, 3]]]`: false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schema), []byte(test), WithUniqueItems())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, but got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestUniqueItemsAndAutomaton(t *testing.T) {
+	schema := `{"type": "array", "items": {"type": "integer"}, "maxItems": 2, "uniqueItems": true}`
+	tests := map[string]bool{
+		`[1, 2]`:    true,
+		`[1, 1]`:    false,
+		`[1, "a"]`:  false,
+		`[1, 2, 3]`: false,
+	}
+	for test, want := range tests {
+		t.Run(test, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schema), []byte(test), WithUniqueItems())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("want %v, but got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestUniqueItemsNotSupported(t *testing.T) {
+	if _, err := MatchBytes([]byte(`{"uniqueItems": true}`), []byte(`[1]`)); err == nil {
+		t.Fatal("expected uniqueItems to not be supported without WithUniqueItems")
+	}
+	conditional := map[string]string{
+		`{"anyOf": [{"uniqueItems": true}, {"type": "string"}]}`:                               "anyOf",
+		`{"not": {"uniqueItems": true}}`:                                                       "not",
+		`{"if": {"type": "array"}, "then": {"items": {"uniqueItems": true}}}`:                  "then",
+		`{"oneOf": [{"$ref": "#/$defs/a"}], "$defs": {"a": {"items": {"uniqueItems": true}}}}`: "oneOf",
+	}
+	for schema, keyword := range conditional {
+		t.Run(schema, func(t *testing.T) {
+			_, err := MatchBytes([]byte(schema), []byte(`[1]`), WithUniqueItems())
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), keyword) {
+				t.Fatalf("expected error to mention %s, but got %v", keyword, err)
+			}
+		})
+	}
+}
+
+func TestUniqueItemsMetaSchema(t *testing.T) {
+	schema := `{"$ref": "http://json-schema.org/draft-07/schema#"}`
+	if _, err := MatchBytes([]byte(schema), []byte(`{"type": "string"}`)); err != nil {
+		t.Fatalf("the uniqueItems of the meta-schema are removed without WithUniqueItems, but got %v", err)
+	}
+	// the meta-schema contains uniqueItems inside anyOf, which are not removed with WithUniqueItems.
+	_, err := MatchBytes([]byte(schema), []byte(`{"type": "string"}`), WithUniqueItems())
+	if err == nil || !strings.Contains(err.Error(), "uniqueItems is only supported where it always applies") {
+		t.Fatalf("expected the uniqueItems of the meta-schema to be validated, but got %v", err)
+	}
+}
+
+func TestUniqueItemsMatchParser(t *testing.T) {
+	m, err := NewInterpreter([]byte(`{"uniqueItems": true}`), WithUniqueItems())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := json.NewJSONSchemaParser()
+	p.Init([]byte(`[1, 2]`))
+	if _, err := m.MatchParser(p); err == nil {
+		t.Fatal("expected MatchParser to return an error, since uniqueItems requires the bytes")
+	}
+	// schemas without uniqueItems can still use MatchParser
+	m, err = NewInterpreter([]byte(`{"type": "array"}`), WithUniqueItems())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Init([]byte(`[1, 1]`))
+	got, err := m.MatchParser(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got {
+		t.Fatal("expected match")
+	}
+}
+
+func TestCompilerUniqueItems(t *testing.T) {
+	c := NewCompiler(WithUniqueItems())
+	if err := c.AddResource("http://example.com/tags.json", []byte(`{"type": "array", "uniqueItems": true}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddResource("http://example.com/post.json", []byte(`{"properties": {"tags": {"$ref": "tags.json"}}}`)); err != nil {
+		t.Fatal(err)
+	}
+	m, err := c.Compile("http://example.com/post.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]bool{
+		`{"tags": ["a", "b"]}`: true,
+		`{"tags": ["a", "a"]}`: false,
+	}
+	for test, want := range tests {
+		got, err := m.MatchBytes([]byte(test))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("%s: want %v, but got %v", test, want, got)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"bytes"
+	encjson "encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/output"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/std"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/translate"
+)
+
+// Validator validates values and explains why a value is not valid.
+// It is safe for concurrent use if it is created with WithConcurrentUse, where the slower engine explains one value at a time.
+type Validator struct {
+	matcher Matcher
+	// mu guards the explainer, which is not safe for concurrent use.
+	mu        sync.Mutex
+	explainer *translate.Explainer
+}
+
+// NewValidator returns a Validator, which matches values with a compiled matcher and only explains the values that do not match.
+func NewValidator(schemaStr []byte, opts ...Option) (*Validator, error) {
+	m, err := Compile(schemaStr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	o := newOptions(opts)
+	e, err := translate.NewExplainer(schemaStr, o.version, o.translateOptions(o.newUniqueItems())...)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{
+		matcher:   m,
+		explainer: e,
+	}, nil
+}
+
+// Validate returns the result of validating the value, which can be output in the flag, basic, detailed and verbose formats.
+// A value that does not match is validated again by a slower engine that explains why,
+// where each error has the location of the value, the location of the keyword and a message.
+// If the explanation says that the value is valid after all, then an error is returned that wraps ErrInternal.
+// A value that matches is not explained, so its result only says that it is valid,
+// which means that its detailed and verbose output formats contain no annotations, see Annotate for the annotations of a valid value.
+func (v *Validator) Validate(jsonStr []byte) (*output.Unit, error) {
+	valid, err := v.matcher.MatchBytes(jsonStr)
+	var matchErr *MatchError
+	if err != nil && !errors.As(err, &matchErr) {
+		return nil, err
+	}
+	if valid {
+		return &output.Unit{Valid: true}, nil
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	u, err := v.explainer.Explain(jsonStr)
+	if err != nil {
+		return nil, err
+	}
+	if u.Valid {
+		return nil, fmt.Errorf("%w: the value does not match, but the explanation is valid", ErrInternal)
+	}
+	return u, nil
+}
+
+// ErrInternal is returned by Validate if the slower engine that explains a value disagrees with the matcher, which is a bug.
+var ErrInternal = errors.New("internal error")
+
+// Validate validates the value against the schema and returns the result, see Validator.Validate.
+func Validate(schemaStr []byte, jsonStr []byte, opts ...Option) (*output.Unit, error) {
+	v, err := NewValidator(schemaStr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return v.Validate(jsonStr)
+}
+
+// ErrNoMatch is returned by Annotate if the value does not match the schema.
+var ErrNoMatch = errors.New("value does not match the schema")
+
+// Annotate returns the annotations that apply to each location in a valid value, where the locations are json pointers, see translate.Explainer.Annotate.
+// The annotations are title, description, default, examples and deprecated, including those of references, allOf and the subschemas of anyOf, oneOf and if that match.
+// If the value does not match, then an error is returned that wraps ErrNoMatch, which can be explained with Validate.
+func (v *Validator) Annotate(jsonStr []byte) (map[string]*output.Annotations, error) {
+	if err := v.match(jsonStr); err != nil {
+		return nil, err
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.explainer.Annotate(jsonStr)
+}
+
+// Annotate returns the annotations that apply to each location in a valid value, see Validator.Annotate.
+func Annotate(schemaStr []byte, jsonStr []byte, opts ...Option) (map[string]*output.Annotations, error) {
+	v, err := NewValidator(schemaStr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return v.Annotate(jsonStr)
+}
+
+// match returns an error that wraps ErrNoMatch, if the value does not match.
+func (v *Validator) match(jsonStr []byte) error {
+	valid, err := v.matcher.MatchBytes(jsonStr)
+	var matchErr *MatchError
+	if errors.As(err, &matchErr) {
+		return fmt.Errorf("%w: %w", ErrNoMatch, err)
+	}
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return ErrNoMatch
+	}
+	return nil
+}
+
+// ApplyDefaults returns the value with the defaults of its absent properties inserted, see translate.Explainer.ApplyDefaults.
+// If the value does not match, then an error is returned that wraps ErrNoMatch, like Annotate, which can be explained with Validate.
+// Otherwise the result is matched again with the same matcher, since a default can be invalid, and if it does not match,
+// then an error is returned that wraps ErrNoMatch and says that it is the value with the defaults that does not match.
+// The result is encoded again, so the properties of objects are sorted and the whitespace is removed.
+func (v *Validator) ApplyDefaults(jsonStr []byte) ([]byte, error) {
+	if err := v.match(jsonStr); err != nil {
+		return nil, err
+	}
+	var value any
+	if err := std.UnmarshalJSON(jsonStr, &value); err != nil {
+		return nil, err
+	}
+	v.mu.Lock()
+	err := v.explainer.ApplyDefaults(value)
+	v.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := encjson.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	data := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	valid, err := v.matcher.MatchBytes(data)
+	var matchErr *MatchError
+	if errors.As(err, &matchErr) {
+		return nil, fmt.Errorf("%w with defaults: %w", ErrNoMatch, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, fmt.Errorf("%w with defaults", ErrNoMatch)
+	}
+	return data, nil
+}
+
+// ApplyDefaults returns the value with the defaults of its absent properties inserted, see Validator.ApplyDefaults.
+func ApplyDefaults(schemaStr []byte, jsonStr []byte, opts ...Option) ([]byte, error) {
+	v, err := NewValidator(schemaStr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return v.ApplyDefaults(jsonStr)
+}
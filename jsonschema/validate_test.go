@@ -0,0 +1,237 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/katydid/parser-go/parse"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/output"
+)
+
+// location is the locations and message of an output unit.
+type location struct {
+	keyword  string
+	absolute string
+	instance string
+	error    string
+}
+
+func basicLocations(u *output.Unit) []location {
+	var locs []location
+	for _, e := range u.Basic().Errors {
+		locs = append(locs, location{e.KeywordLocation, e.AbsoluteKeywordLocation, e.InstanceLocation, e.Error})
+	}
+	return locs
+}
+
+func TestValidate(t *testing.T) {
+	schemaStr := `
+    {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "age": {"type": "integer", "minimum": 0}
+      },
+      "required": ["name"]
+    }`
+	tests := []struct {
+		value string
+		want  []location
+	}{
+		{`{"name": "Bob", "age": 1}`, nil},
+		{`{"age": -1}`, []location{
+			{"/required", "", "", `missing required properties "name"`},
+			{"/properties", "", "", "some properties do not match properties"},
+			{"/properties/age/minimum", "", "/age", "value must be greater than or equal to 0"},
+		}},
+		{`{"name": 1}`, []location{
+			{"/properties", "", "", "some properties do not match properties"},
+			{"/properties/name/type", "", "/name", "expected string, but got integer"},
+		}},
+		{`[]`, []location{
+			{"/type", "", "", "expected object, but got array"},
+		}},
+	}
+	v, err := NewValidator([]byte(schemaStr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			u, err := v.Validate([]byte(test.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Valid != (test.want == nil) {
+				t.Fatalf("want valid %v, but got %v", test.want == nil, u.Valid)
+			}
+			if got := basicLocations(u); !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("want %v, but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateRef(t *testing.T) {
+	schemaStr := `
+    {
+      "$id": "http://example.com/list.json",
+      "items": {"$ref": "#/$defs/positive"},
+      "$defs": {
+        "positive": {"type": "number", "exclusiveMinimum": 0}
+      }
+    }`
+	u, err := Validate([]byte(schemaStr), []byte(`[1, -1]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []location{
+		{"/items", "http://example.com/list.json#/items", "", "some items do not match items"},
+		{"/items/$ref", "http://example.com/list.json#/items/$ref", "/1", "value does not match the referenced schema"},
+		{"/items/$ref/exclusiveMinimum", "http://example.com/list.json#/$defs/positive/exclusiveMinimum", "/1", "value must be greater than 0"},
+	}
+	if got := basicLocations(u); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
+
+func TestValidateApplicators(t *testing.T) {
+	schemaStr := `
+    {
+      "anyOf": [{"type": "string"}, {"type": "integer"}],
+      "not": {"const": 3.5}
+    }`
+	u, err := Validate([]byte(schemaStr), []byte(`3.5`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []location{
+		{"/anyOf", "", "", "value does not match any of the schemas of anyOf"},
+		{"/anyOf/0/type", "", "", "expected string, but got number"},
+		{"/anyOf/1/type", "", "", "expected integer, but got number"},
+		{"/not", "", "", "value matches the schema of not"},
+	}
+	if got := basicLocations(u); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
+
+func TestValidateFormats(t *testing.T) {
+	schemaStr := `{"properties": {"a": {"properties": {"b": {"maxLength": 1}}}}}`
+	u, err := Validate([]byte(schemaStr), []byte(`{"a": {"b": "xy"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		format any
+		want   string
+	}{
+		{"flag", u.Flag(), `{"valid":false}`},
+		{"basic", u.Basic(), `{"valid":false,"keywordLocation":"","instanceLocation":"","errors":[` +
+			`{"valid":false,"keywordLocation":"/properties","instanceLocation":"","error":"some properties do not match properties"},` +
+			`{"valid":false,"keywordLocation":"/properties/a/properties","instanceLocation":"/a","error":"some properties do not match properties"},` +
+			`{"valid":false,"keywordLocation":"/properties/a/properties/b/maxLength","instanceLocation":"/a/b","error":"string is longer than 1 characters"}]}`},
+		{"detailed", u.Detailed(), `{"valid":false,"keywordLocation":"","instanceLocation":"","errors":[` +
+			`{"valid":false,"keywordLocation":"/properties","instanceLocation":"","error":"some properties do not match properties","errors":[` +
+			`{"valid":false,"keywordLocation":"/properties/a/properties","instanceLocation":"/a","error":"some properties do not match properties","errors":[` +
+			`{"valid":false,"keywordLocation":"/properties/a/properties/b/maxLength","instanceLocation":"/a/b","error":"string is longer than 1 characters"}]}]}]}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.want {
+				t.Fatalf("want %s, but got %s", test.want, data)
+			}
+		})
+	}
+}
+
+func TestValidateValid(t *testing.T) {
+	u, err := Validate([]byte(`{"type": "string"}`), []byte(`"a"`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !u.Valid || len(u.Errors) != 0 {
+		t.Fatalf("expected a valid result without errors, but got %#v", u)
+	}
+	// a valid value is not explained, so even the verbose output has no annotations.
+	want := `{"valid":true,"keywordLocation":"","instanceLocation":""}`
+	for name, format := range map[string]*output.Unit{"detailed": u.Detailed(), "verbose": u.Verbose()} {
+		data, err := json.Marshal(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Fatalf("want %s %s, but got %s", name, want, data)
+		}
+	}
+}
+
+func TestValidateInternalError(t *testing.T) {
+	v, err := NewValidator([]byte(`{"type": "string"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a matcher that disagrees with the explainer.
+	v.matcher = noMatcher{}
+	if _, err := v.Validate([]byte(`"a"`)); !errors.Is(err, ErrInternal) {
+		t.Fatalf("want internal error, but got %v", err)
+	}
+}
+
+// noMatcher is a Matcher that matches no values.
+type noMatcher struct{}
+
+func (noMatcher) MatchBytes(jsonStr []byte) (bool, error) {
+	return false, nil
+}
+
+func (noMatcher) MatchParser(p parse.Parser) (bool, error) {
+	return false, nil
+}
+
+func TestCompilerValidator(t *testing.T) {
+	c := NewCompiler()
+	if err := c.AddResource("http://example.com/name.json", []byte(`{"type": "string"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddResource("http://example.com/person.json", []byte(`{"properties": {"name": {"$ref": "name.json"}}}`)); err != nil {
+		t.Fatal(err)
+	}
+	v, err := c.NewValidator("http://example.com/person.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := v.Validate([]byte(`{"name": 1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []location{
+		{"/properties", "http://example.com/person.json#/properties", "", "some properties do not match properties"},
+		{"/properties/name/$ref", "http://example.com/person.json#/properties/name/$ref", "/name", "value does not match the referenced schema"},
+		{"/properties/name/$ref/type", "http://example.com/name.json#/type", "/name", "expected string, but got integer"},
+	}
+	if got := basicLocations(u); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/katydid/validator-go-jsonschema/jsonschema/loader"
+	"github.com/katydid/validator-go-jsonschema/jsonschema/schema"
+)
+
+var vocabularyMetaSchemas = loader.Map{
+	"http://example.com/no-validation.json": []byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id": "http://example.com/no-validation.json",
+		"$vocabulary": {
+			"https://json-schema.org/draft/2020-12/vocab/core": true,
+			"https://json-schema.org/draft/2020-12/vocab/applicator": true
+		}
+	}`),
+	"http://example.com/format-annotation.json": []byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id": "http://example.com/format-annotation.json",
+		"$vocabulary": {
+			"https://json-schema.org/draft/2020-12/vocab/core": true,
+			"https://json-schema.org/draft/2020-12/vocab/validation": true,
+			"https://json-schema.org/draft/2020-12/vocab/format-annotation": true
+		}
+	}`),
+	"http://example.com/format-assertion.json": []byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id": "http://example.com/format-assertion.json",
+		"$vocabulary": {
+			"https://json-schema.org/draft/2020-12/vocab/core": true,
+			"https://json-schema.org/draft/2020-12/vocab/format-assertion": false,
+			"http://example.com/vocab/optional": false
+		}
+	}`),
+	"http://example.com/unknown-vocabulary.json": []byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id": "http://example.com/unknown-vocabulary.json",
+		"$vocabulary": {
+			"https://json-schema.org/draft/2020-12/vocab/core": true,
+			"http://example.com/vocab/required": true
+		}
+	}`),
+}
+
+func TestVocabularyFromMetaSchema(t *testing.T) {
+	tests := []struct {
+		schema string
+		value  string
+		want   bool
+	}{
+		// without the validation vocabulary minimum is ignored, but properties still applies.
+		{`{"$schema": "http://example.com/no-validation.json", "properties": {"a": {"minimum": 10}, "b": false}}`, `{"a": 1}`, true},
+		{`{"$schema": "http://example.com/no-validation.json", "properties": {"a": {"minimum": 10}, "b": false}}`, `{"b": 1}`, false},
+		{`{"$schema": "http://example.com/format-annotation.json", "format": "ipv4", "maxLength": 9}`, `"not-ipv4"`, true},
+		{`{"$schema": "http://example.com/format-annotation.json", "format": "ipv4", "maxLength": 9}`, `"not-an-ipv4"`, false},
+		{`{"$schema": "http://example.com/format-assertion.json", "format": "ipv4"}`, `"127.0.0.1"`, true},
+		{`{"$schema": "http://example.com/format-assertion.json", "format": "ipv4"}`, `"not-ipv4"`, false},
+		// the official dialects keep format as a hard check.
+		{`{"$schema": "https://json-schema.org/draft/2020-12/schema", "format": "ipv4"}`, `"not-ipv4"`, false},
+	}
+	for _, test := range tests {
+		t.Run(test.schema+test.value, func(t *testing.T) {
+			got, err := MatchBytes([]byte(test.schema), []byte(test.value), WithLoader(vocabularyMetaSchemas))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestVocabularyUnknownRequired(t *testing.T) {
+	schemaStr := `{"$schema": "http://example.com/unknown-vocabulary.json", "type": "string"}`
+	_, err := MatchBytes([]byte(schemaStr), []byte(`"a"`), WithLoader(vocabularyMetaSchemas))
+	if err == nil {
+		t.Fatal("expected an error for an unknown required vocabulary")
+	}
+	if !strings.Contains(err.Error(), "http://example.com/vocab/required") {
+		t.Fatalf("expected the error to mention the vocabulary, but got %v", err)
+	}
+}
+
+func TestVocabularyWithDialect(t *testing.T) {
+	schemaStr := `
+    {
+      "$schema": "https://example.com/dialect",
+      "type": "object",
+      "properties": {
+        "ip": {"type": "string", "format": "ipv4", "x-extra": true},
+        "items": {"prefixItems": [{"type": "integer"}]}
+      }
+    }`
+	full := WithDialect("https://example.com/dialect", schema.VersionDraft2020)
+	noFormat := WithDialect("https://example.com/dialect", schema.VersionDraft2020,
+		schema.VocabularyCore,
+		schema.VocabularyApplicator,
+		schema.VocabularyValidation,
+		schema.VocabularyFormatAnnotation,
+	)
+	tests := []struct {
+		opt   Option
+		value string
+		want  bool
+	}{
+		{full, `{"ip": "127.0.0.1"}`, true},
+		{full, `{"ip": "localhost"}`, false},
+		{full, `{"items": [1, "a"]}`, true},
+		{full, `{"items": ["a"]}`, false},
+		{noFormat, `{"ip": "localhost"}`, true},
+		{noFormat, `{"ip": 1}`, false},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := MatchBytes([]byte(schemaStr), []byte(test.value), test.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}